@@ -2,7 +2,9 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"go_client/config"
 	"go_client/engine"
 	"os"
 	"os/signal"
@@ -13,19 +15,34 @@ func main() {
 	args := os.Args
 
 	_engine, err := engine.NewDetectEngine(args)
-	if err != nil {
-		fmt.Println("[-] new detect engin err:", err)
+	if errors.Is(err, config.ErrConfigPrinted) {
 		return
 	}
+	if err != nil {
+		// 配置错误等启动失败以非 0 退出，便于 systemd、k8s 等识别
+		fmt.Fprintln(os.Stderr, "[-] new detect engin err:", err)
+		os.Exit(1)
+	}
 	fmt.Println("[+] new detect engin success")
 
 	ch := make(chan os.Signal, 1)
 
 	_engine.Run(ch)
 
+	// SIGHUP 重新加载配置
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for range hup {
+			_engine.ReloadConfig()
+		}
+	}()
+
 	signal.Notify(ch, syscall.SIGTERM, syscall.SIGINT)
 	<-ch
 	fmt.Println("[-] detect engine shutdown")
-	_engine.Close()
+	if err := _engine.Close(); err != nil {
+		fmt.Println("[-] detect engine shutdown err:", err)
+	}
 
 }
@@ -1,9 +1,17 @@
 package config
 
 import (
+	"errors"
 	"flag"
+	"fmt"
 	"github.com/pelletier/go-toml/v2"
+	"go_client/pkg/auth"
+	"maps"
+	"net/url"
 	"os"
+	"reflect"
+	"slices"
+	"strings"
 )
 
 type Config struct {
@@ -11,16 +19,38 @@ type Config struct {
 	Server Server `toml:"server"`
 	Engine Engine `toml:"engine"`
 	Logger Logger `toml:"logger"`
+	Auth   Auth   `toml:"auth"`
+
+	Tracing Tracing `toml:"tracing"` // OpenTelemetry 链路追踪
+
+	Sessions []DeclaredSession `toml:"sessions"` // 声明式会话，文件变化时自动调和
+}
+
+// Path 配置文件路径
+func (c *Config) Path() string {
+	return c.env.configPath
 }
 
 type env struct {
-	configPath string `toml:"config-path"`
+	configPath string            `toml:"config-path"`
+	flags      map[string]string // 命令行覆盖项 toml 路径 -> 值，重新加载时沿用
 }
 
 type Server struct {
-	UseH2C         bool   `toml:"use-h2c"`
-	ListenHttpAddr string `toml:"listen-http-addr"`
-	GrpcPeerAddr   string `toml:"grpc-peer-addr"`
+	UseH2C          bool   `toml:"use-h2c"`
+	ListenHttpAddr  string `toml:"listen-http-addr"`
+	GrpcPeerAddr    string `toml:"grpc-peer-addr"`
+	ShutdownTimeout int32  `toml:"shutdown-timeout"` // 优雅关闭超时时间 s
+	TLS             TLS    `toml:"tls"`
+}
+
+// TLS HTTP 与 gRPC 监听 TLS 配置，证书文件变化时自动重新加载
+type TLS struct {
+	Enable         bool   `toml:"enable"`           // 是否开启 TLS
+	CertFile       string `toml:"cert-file"`        // 服务端证书
+	KeyFile        string `toml:"key-file"`         // 服务端私钥
+	ClientCAFile   string `toml:"client-ca-file"`   // 客户端 CA，设置后 gRPC 强制 mTLS
+	HTTPClientAuth bool   `toml:"http-client-auth"` // HTTP 是否同样强制校验客户端证书，否则仅校验提供的证书
 }
 
 type Engine struct {
@@ -32,8 +62,217 @@ type Engine struct {
 	PushUrlInternalPre string `toml:"push-url-internal-pre"` // 推流使用前缀 ：如 rtmp://rtmp-server/live/stream
 	PushUrlPublicPre   string `toml:"push-url-public-pre"`   // 播放展示用：如 rtmp://mydomain.com/live/stream
 
+	Encoding         EncodingProfile            `toml:"encoding"`          // 推流默认编码配置
+	EncodingProfiles map[string]EncodingProfile `toml:"encoding-profiles"` // 命名编码配置，会话可按名称引用
+
+	Quota Quota `toml:"quota"` // 会话配额与准入控制
+
+	Watchdog Watchdog `toml:"watchdog"` // 卡流看门狗
+
+	AuditLog        string `toml:"audit-log"`         // 会话生命周期审计日志路径（追加写入），为空不开启
+	AuditMaxSize    int    `toml:"audit-max-size"`    // 审计日志单个文件最大大小（MB），超出后轮转，默认 100
+	AuditMaxAge     int    `toml:"audit-max-age"`     // 轮转后的审计日志保留天数，0 表示永久保留
+	AuditMaxBackups int    `toml:"audit-max-backups"` // 轮转后的审计日志最大保留数量，0 表示不限制
+
+	StatsDB        string `toml:"stats-db"`        // 识别统计数据库路径（按会话每分钟聚合），为空不开启
+	StatsRetention int    `toml:"stats-retention"` // 识别统计保留天数，0 表示永久保留
+	EventDB        string `toml:"event-db"`        // 识别事件历史数据库路径，仅保存有识别结果的事件，为空不开启
+	EventRetention int    `toml:"event-retention"` // 识别事件保留天数，0 表示永久保留
+	EventWebhook   string `toml:"event-webhook"`   // 识别事件推送地址，按批 POST JSON 数组，仅推送有识别结果的事件，为空不开启
+}
+
+// Watchdog 卡流看门狗：FFmpeg 未退出但长时间没有帧时重启卡住的 FFmpeg，连续重启仍无法恢复时会话失败
+type Watchdog struct {
+	StallTimeout int32 `toml:"stall-timeout"` // 超过该时间 s 没有推流帧视为卡流，0 表示关闭
+	MaxRestarts  int   `toml:"max-restarts"`  // 连续重启次数上限，期间推流恢复则清零；超过后会话失败
+}
+
+// Quota 会话配额，成本按 宽×高×帧率（像素/秒）计算，0 表示不限制
+type Quota struct {
+	MaxSessions  int                    `toml:"max-sessions"`   // 全局最大会话数
+	MaxPixelRate int64                  `toml:"max-pixel-rate"` // 全局成本上限：所有会话像素/秒之和
+	Tenant       TenantQuota            `toml:"tenant"`         // 每个租户的默认配额
+	Tenants      map[string]TenantQuota `toml:"tenants"`        // 按租户覆盖默认配额
+}
+
+// TenantQuota 租户配额，0 表示不限制
+type TenantQuota struct {
+	MaxSessions  int   `toml:"max-sessions"`
+	MaxPixelRate int64 `toml:"max-pixel-rate"`
+}
+
+// TenantQuota 获取租户配额，未单独配置时使用默认配额
+func (q Quota) TenantQuota(tenant string) TenantQuota {
+	if tq, ok := q.Tenants[tenant]; ok {
+		return tq
+	}
+	return q.Tenant
+}
+
+// 支持的推流编码器
+const (
+	CodecH264  = "libx264"
+	CodecH265  = "libx265"
+	CodecVP8   = "libvpx"
+	CodecMJPEG = "mjpeg"
+)
+
+var SupportedCodecs = []string{CodecH264, CodecH265, CodecVP8, CodecMJPEG}
+
+// EncodingProfile 推流编码配置，零值字段表示使用 FFmpeg 默认值
+type EncodingProfile struct {
+	Codec        string `toml:"codec" json:"codec,omitempty"`                // 编码器 libx264 libx265 libvpx mjpeg，默认 libx264
+	Preset       string `toml:"preset" json:"preset,omitempty"`              // 编码预设，如 libx264 veryfast；libvpx 对应 deadline：realtime good best
+	Tune         string `toml:"tune" json:"tune,omitempty"`                  // 编码调优，如 zerolatency
+	Bitrate      int    `toml:"bitrate" json:"bitrate,omitempty"`            // 码率 kbps，设置后优先于 crf
+	CRF          int    `toml:"crf" json:"crf,omitempty"`                    // 质量因子；mjpeg 对应 -q:v (2-31)
+	GOP          int    `toml:"gop" json:"gop,omitempty"`                    // 关键帧间隔（帧）
+	OutputWidth  int    `toml:"output-width" json:"outputWidth,omitempty"`   // 推流输出宽，0 表示与识别分辨率一致
+	OutputHeight int    `toml:"output-height" json:"outputHeight,omitempty"` // 推流输出高，0 表示与识别分辨率一致
+	Format       string `toml:"format" json:"format,omitempty"`              // 输出封装格式，默认 flv
+
+	Clear []string `toml:"clear" json:"clear,omitempty"` // 合并时恢复为 FFmpeg 默认值的字段，取值为上述 toml 键名，如 ["bitrate", "tune"]
+}
+
+// encodingFields 可通过 Clear 清除的字段
+var encodingFields = map[string]func(p *EncodingProfile){
+	"codec":         func(p *EncodingProfile) { p.Codec = "" },
+	"preset":        func(p *EncodingProfile) { p.Preset = "" },
+	"tune":          func(p *EncodingProfile) { p.Tune = "" },
+	"bitrate":       func(p *EncodingProfile) { p.Bitrate = 0 },
+	"crf":           func(p *EncodingProfile) { p.CRF = 0 },
+	"gop":           func(p *EncodingProfile) { p.GOP = 0 },
+	"output-width":  func(p *EncodingProfile) { p.OutputWidth = 0 },
+	"output-height": func(p *EncodingProfile) { p.OutputHeight = 0 },
+	"format":        func(p *EncodingProfile) { p.Format = "" },
+}
+
+// 各编码器质量因子取值范围，0 表示未设置
+var crfRanges = map[string][2]int{
+	CodecH264:  {0, 51},
+	CodecH265:  {0, 51},
+	CodecVP8:   {4, 63},
+	CodecMJPEG: {2, 31},
+}
+
+// VP8Deadlines libvpx 支持的 deadline，preset 不在其中时忽略
+var VP8Deadlines = []string{"realtime", "good", "best"}
+
+// Merge 先清除 override.Clear 列出的字段，再使用 override 中的非零字段覆盖当前配置；
+// 无法识别的 Clear 字段保留在结果中，由 Validate 报告
+func (p EncodingProfile) Merge(override EncodingProfile) EncodingProfile {
+	p.Clear = nil
+	for _, field := range override.Clear {
+		if clear, ok := encodingFields[field]; ok {
+			clear(&p)
+		} else {
+			p.Clear = append(p.Clear, field)
+		}
+	}
+	if override.Codec != "" {
+		p.Codec = override.Codec
+	}
+	if override.Preset != "" {
+		p.Preset = override.Preset
+	}
+	if override.Tune != "" {
+		p.Tune = override.Tune
+	}
+	if override.Bitrate != 0 {
+		p.Bitrate = override.Bitrate
+	}
+	if override.CRF != 0 {
+		p.CRF = override.CRF
+	}
+	if override.GOP != 0 {
+		p.GOP = override.GOP
+	}
+	if override.OutputWidth != 0 {
+		p.OutputWidth = override.OutputWidth
+	}
+	if override.OutputHeight != 0 {
+		p.OutputHeight = override.OutputHeight
+	}
+	if override.Format != "" {
+		p.Format = override.Format
+	}
+	return p
+}
+
+// Validate 校验编码配置
+func (p EncodingProfile) Validate() error {
+	if p.Codec != "" && !slices.Contains(SupportedCodecs, p.Codec) {
+		return fmt.Errorf("unsupported codec %q, supported: %s", p.Codec, strings.Join(SupportedCodecs, ", "))
+	}
+	if p.Bitrate < 0 {
+		return fmt.Errorf("bitrate must not be negative: %d", p.Bitrate)
+	}
+	for _, field := range p.Clear {
+		if _, ok := encodingFields[field]; !ok {
+			return fmt.Errorf("unknown clear field %q", field)
+		}
+	}
+	codec := p.Codec
+	if codec == "" {
+		codec = CodecH264
+	}
+	if crfRange := crfRanges[codec]; p.CRF != 0 && (p.CRF < crfRange[0] || p.CRF > crfRange[1]) {
+		return fmt.Errorf("crf out of range [%d, %d] for %s: %d", crfRange[0], crfRange[1], codec, p.CRF)
+	}
+	if codec == CodecVP8 && (p.Format == "" || p.Format == "flv") {
+		return fmt.Errorf("%s cannot be muxed into flv/RTMP, set format explicitly (e.g. webm)", codec)
+	}
+	if p.GOP < 0 {
+		return fmt.Errorf("gop must not be negative: %d", p.GOP)
+	}
+	if p.OutputWidth < 0 || p.OutputHeight < 0 || (p.OutputWidth == 0) != (p.OutputHeight == 0) {
+		return fmt.Errorf("output-width and output-height must be both set or both zero: %dx%d", p.OutputWidth, p.OutputHeight)
+	}
+	return nil
+}
+
+// Auth API 认证配置，开启后 HTTP 与 gRPC 均需携带 API Key 或 JWT
+type Auth struct {
+	Enable  bool           `toml:"enable"`   // 是否开启认证
+	APIKeys []auth.APIKey  `toml:"api-keys"` // API Key 列表
+	JWT     auth.JWTConfig `toml:"jwt"`      // JWT 校验配置，secret 为空不开启 JWT
+}
+
+// DeclaredSession 配置文件声明的会话
+type DeclaredSession struct {
+	ID              string            `toml:"id"`
+	RtspURL         string            `toml:"rtsp-url"`
+	Width           int               `toml:"width"`
+	Height          int               `toml:"height"`
+	Framerate       int               `toml:"framerate"`
+	RetryTimes      int               `toml:"retry-times"`      // 读帧失败重试次数，默认 3
+	Detect          bool              `toml:"detect"`           // 是否识别，仅在创建或声明变更时下发
+	EncodingProfile string            `toml:"encoding-profile"` // 命名编码配置
+	Tenant          string            `toml:"tenant"`           // 所属租户
+	Name            string            `toml:"name"`
+	Site            string            `toml:"site"`
+	Labels          map[string]string `toml:"labels"`
+}
+
+// SameImmutable 创建后不可修改的字段（租户、名称、站点、标签、重试次数）是否一致，不一致时需重建会话
+func (d DeclaredSession) SameImmutable(o DeclaredSession) bool {
+	return d.Tenant == o.Tenant && d.Name == o.Name && d.Site == o.Site && maps.Equal(d.Labels, o.Labels) &&
+		d.RetryTimes == o.RetryTimes
 }
 
+// Equal 声明是否完全一致
+func (d DeclaredSession) Equal(o DeclaredSession) bool {
+	return d.SameImmutable(o) && d.ID == o.ID && d.RtspURL == o.RtspURL &&
+		d.Width == o.Width && d.Height == o.Height && d.Framerate == o.Framerate &&
+		d.Detect == o.Detect && d.EncodingProfile == o.EncodingProfile
+}
+
+// 日志输出格式
+const (
+	LogFormatConsole = "console"
+	LogFormatJSON    = "json"
+)
+
 type Logger struct {
 	LocalTime    bool   `toml:"local-time"`     // 是否使用本地时间，默认使用UTC
 	Compress     bool   `toml:"compress"`       // 是否使用GZIP格式压缩，默认不压缩
@@ -42,17 +281,144 @@ type Logger struct {
 	MaxBackups   int    `toml:"max-backups"`    // 志最大存储数量（会被MaxAge删除）
 	LogPath      string `toml:"log-path"`       // 日志路径
 	LogLevel     string `toml:"log-level"`      // 日志等级
+	Format       string `toml:"format"`         // 输出格式 console json，默认 console
+	Stdout       bool   `toml:"stdout"`         // 是否同时输出到标准输出
+}
+
+// 链路追踪导出方式
+const (
+	TracingExporterOTLPGRPC = "otlp-grpc"
+	TracingExporterOTLPHTTP = "otlp-http"
+	TracingExporterStdout   = "stdout"
+	TracingExporterFile     = "file"
+)
+
+var TracingExporters = []string{TracingExporterOTLPGRPC, TracingExporterOTLPHTTP, TracingExporterStdout, TracingExporterFile}
+
+// Tracing OpenTelemetry 链路追踪配置
+type Tracing struct {
+	Enable      bool    `toml:"enable"`       // 是否开启链路追踪
+	ServiceName string  `toml:"service-name"` // 服务名，默认 video_detect
+	Exporter    string  `toml:"exporter"`     // 导出方式 otlp-grpc otlp-http stdout file，默认 otlp-grpc
+	Endpoint    string  `toml:"endpoint"`     // OTLP 地址 host:port，为空时使用 OTEL_EXPORTER_OTLP_ENDPOINT
+	Insecure    bool    `toml:"insecure"`     // OTLP 不使用 TLS
+	File        string  `toml:"file"`         // file 导出的文件路径，每行一个 span（JSON）
+	SampleRatio float64 `toml:"sample-ratio"` // 新 trace 的采样比例 (0, 1]，0 使用默认 0.1；上游已采样时跟随上游
 }
 
+// Service 服务名，未配置时使用默认值
+func (c Tracing) Service() string {
+	if c.ServiceName == "" {
+		return "video_detect"
+	}
+	return c.ServiceName
+}
+
+// ErrConfigPrinted 指定 -print-config 时已输出配置，调用方应直接退出
+var ErrConfigPrinted = errors.New("config printed")
+
+// redactedValue 输出配置时替换敏感值
+const redactedValue = "******"
+
+// Redacted 返回脱敏后的配置副本：API Key、JWT 密钥与 URL 中的密码被替换
+func (c Config) Redacted() Config {
+	c.Auth.APIKeys = slices.Clone(c.Auth.APIKeys)
+	for i := range c.Auth.APIKeys {
+		c.Auth.APIKeys[i].Key = redactedValue
+	}
+	if c.Auth.JWT.Secret != "" {
+		c.Auth.JWT.Secret = redactedValue
+	}
+
+	c.Engine.DetectAIURL = redactURL(c.Engine.DetectAIURL)
+	c.Engine.PushUrlInternalPre = redactURL(c.Engine.PushUrlInternalPre)
+	c.Engine.PushUrlPublicPre = redactURL(c.Engine.PushUrlPublicPre)
+	c.Engine.EventWebhook = redactURL(c.Engine.EventWebhook)
+	c.Sessions = slices.Clone(c.Sessions)
+	for i := range c.Sessions {
+		c.Sessions[i].RtspURL = redactURL(c.Sessions[i].RtspURL)
+	}
+	return c
+}
+
+// redactURL 替换 URL 中的密码，无法解析的 URL 原样返回
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.User == nil {
+		return raw
+	}
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), redactedValue)
+	}
+	return u.String()
+}
+
+// BindConfig 读取配置，优先级从高到低：命令行参数 > VIDEO_DETECT_* 环境变量 > 配置文件 > 默认值
+//
+// server、engine、logger、tracing 下的每个标量配置项都可覆盖，名称由 toml 路径生成，如 engine.detect-ai-url：
+// 命令行参数 -engine.detect-ai-url，环境变量 VIDEO_DETECT_ENGINE_DETECT_AI_URL；
+// 配置文件路径由 -config-path 或 VIDEO_DETECT_CONFIG_PATH 指定；-print-config 输出脱敏后的合并配置并返回 ErrConfigPrinted
 func BindConfig(args []string) (*Config, error) {
-	var config Config
+	var (
+		configPath  string
+		printConfig bool
+		flags       = make(map[string]string)
+	)
+	defaultPath := "./video_detect.toml"
+	if path, ok := os.LookupEnv(EnvPrefix + "CONFIG_PATH"); ok {
+		defaultPath = path
+	}
+
 	fs := flag.NewFlagSet("config", flag.ExitOnError)
-	fs.StringVar(&config.env.configPath, "config-path", "./video_detect.toml", "detect config path")
+	fs.StringVar(&configPath, "config-path", defaultPath, "detect config path (env "+EnvPrefix+"CONFIG_PATH)")
+	fs.BoolVar(&printConfig, "print-config", false, "print the effective merged config and exit")
+	for _, field := range overrideFields(&Config{}) {
+		key := field.key
+		usage := fmt.Sprintf("override %s (env %s)", key, field.env)
+		set := func(value string) error {
+			flags[key] = value
+			return nil
+		}
+		if field.value.Kind() == reflect.Bool {
+			fs.BoolFunc(key, usage, set)
+		} else {
+			fs.Func(key, usage, set)
+		}
+	}
 
 	if err := fs.Parse(args[1:]); err != nil {
 		return nil, err
 	}
 
+	config, err := loadConfig(configPath, flags)
+	if err != nil {
+		return nil, err
+	}
+
+	if printConfig {
+		if err := toml.NewEncoder(os.Stdout).Encode(config.Redacted()); err != nil {
+			return nil, err
+		}
+		return nil, ErrConfigPrinted
+	}
+	return config, nil
+}
+
+// LoadConfig 读取配置文件并应用环境变量覆盖
+func LoadConfig(configPath string) (*Config, error) {
+	return loadConfig(configPath, nil)
+}
+
+// Reload 重新读取配置文件，沿用启动时的命令行覆盖项
+func (c *Config) Reload() (*Config, error) {
+	return loadConfig(c.env.configPath, c.env.flags)
+}
+
+func loadConfig(configPath string, flags map[string]string) (*Config, error) {
+	var config Config
+	config.env.configPath = configPath
+	config.env.flags = flags
+
 	open, err := os.Open(config.env.configPath)
 	defer func() {
 		_ = open.Close() // 确保文件在函数返回前被关闭
@@ -66,5 +432,9 @@ func BindConfig(args []string) (*Config, error) {
 		return nil, err
 	}
 
+	if err = applyOverrides(&config, flags); err != nil {
+		return nil, err
+	}
+
 	return &config, nil
 }
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const EnvPrefix = "VIDEO_DETECT_"
+
+// overrideField 可通过环境变量与命令行参数覆盖的配置项
+type overrideField struct {
+	key   string        // toml 路径，同时作为命令行参数名，如 engine.detect-ai-url
+	env   string        // 环境变量名，如 VIDEO_DETECT_ENGINE_DETECT_AI_URL
+	value reflect.Value // 可设置的字段值
+}
+
+// overrideFields 列出 server、engine、logger、tracing 下的标量配置项（含嵌套结构体），map 与 slice 不支持覆盖
+func overrideFields(config *Config) []overrideField {
+	root := reflect.ValueOf(config).Elem()
+	fields := make([]overrideField, 0)
+	for _, section := range []string{"Server", "Engine", "Logger", "Tracing"} {
+		field, _ := root.Type().FieldByName(section)
+		fields = collectOverrideFields(fields, tomlKey(field), root.FieldByName(section))
+	}
+	return fields
+}
+
+func collectOverrideFields(fields []overrideField, prefix string, v reflect.Value) []overrideField {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		key := tomlKey(field)
+		if !field.IsExported() || key == "" {
+			continue
+		}
+		key = prefix + "." + key
+		switch field.Type.Kind() {
+		case reflect.Struct:
+			fields = collectOverrideFields(fields, key, v.Field(i))
+		case reflect.String, reflect.Bool, reflect.Int, reflect.Int32, reflect.Int64, reflect.Float64:
+			fields = append(fields, overrideField{key: key, env: envName(key), value: v.Field(i)})
+		}
+	}
+	return fields
+}
+
+func tomlKey(field reflect.StructField) string {
+	key, _, _ := strings.Cut(field.Tag.Get("toml"), ",")
+	if key == "-" {
+		return ""
+	}
+	return key
+}
+
+// envName toml 路径转换为环境变量名
+func envName(key string) string {
+	return EnvPrefix + strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(key))
+}
+
+// applyOverrides 依次应用环境变量与命令行覆盖项，命令行优先
+func applyOverrides(config *Config, flags map[string]string) error {
+	for _, field := range overrideFields(config) {
+		if value, ok := os.LookupEnv(field.env); ok {
+			if err := setFieldValue(field.value, value); err != nil {
+				return fmt.Errorf("env %s: %w", field.env, err)
+			}
+		}
+		if value, ok := flags[field.key]; ok {
+			if err := setFieldValue(field.value, value); err != nil {
+				return fmt.Errorf("flag -%s: %w", field.key, err)
+			}
+		}
+	}
+	return nil
+}
+
+func setFieldValue(v reflect.Value, value string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid bool %q", value)
+		}
+		v.SetBool(b)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", value)
+		}
+		v.SetFloat(f)
+	default:
+		n, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", value)
+		}
+		v.SetInt(n)
+	}
+	return nil
+}
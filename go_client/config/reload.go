@@ -0,0 +1,77 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+)
+
+// hotReloadable 可热更新的配置项（toml 路径前缀），其余配置项变化需重启生效；
+// uvicorn socket、编码配置与配额仅对之后创建的会话生效，运行中的会话保持创建时的设置
+var hotReloadable = []string{
+	"logger.log-level",
+	"engine.detect-ai-url",
+	"engine.healthy-heartbeat",
+	"engine.push-url-internal-pre",
+	"engine.push-url-public-pre",
+	"engine.uvicorn-socket",
+	"engine.socket-path",
+	"engine.encoding",
+	"engine.encoding-profiles",
+	"engine.quota",
+	"engine.watchdog",
+	"sessions",
+}
+
+// ReloadDiff 配置重新加载前后的变化
+type ReloadDiff struct {
+	Applied         []string // 已热更新的配置项
+	RequiresRestart []string // 需重启生效的配置项
+}
+
+// Diff 对比配置变化，按 toml 路径列出变化的配置项并区分是否可热更新
+func Diff(old, new *Config) ReloadDiff {
+	var diff ReloadDiff
+	for _, key := range changedFields("", reflect.ValueOf(*old), reflect.ValueOf(*new)) {
+		if IsHotReloadable(key) {
+			diff.Applied = append(diff.Applied, key)
+		} else {
+			diff.RequiresRestart = append(diff.RequiresRestart, key)
+		}
+	}
+	return diff
+}
+
+// IsHotReloadable 配置项是否可热更新
+func IsHotReloadable(key string) bool {
+	for _, prefix := range hotReloadable {
+		if key == prefix || strings.HasPrefix(key, prefix+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// changedFields 递归对比结构体字段，map、slice 等整体对比
+func changedFields(prefix string, old, new reflect.Value) []string {
+	var changed []string
+	for i := 0; i < old.NumField(); i++ {
+		field := old.Type().Field(i)
+		tag, _, _ := strings.Cut(field.Tag.Get("toml"), ",")
+		if !field.IsExported() || tag == "" || tag == "-" {
+			continue
+		}
+		key := tag
+		if prefix != "" {
+			key = prefix + "." + tag
+		}
+		oldField, newField := old.Field(i), new.Field(i)
+		if field.Type.Kind() == reflect.Struct {
+			changed = append(changed, changedFields(key, oldField, newField)...)
+			continue
+		}
+		if !reflect.DeepEqual(oldField.Interface(), newField.Interface()) {
+			changed = append(changed, key)
+		}
+	}
+	return changed
+}
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	old := &Config{}
+	old.Server.ListenHttpAddr = ":8080"
+	old.Engine.DetectAIURL = "http://ai-1/detect"
+	old.Logger.LogLevel = "info"
+
+	updated := *old
+	updated.Server.ListenHttpAddr = ":9090"
+	updated.Engine.DetectAIURL = "http://ai-2/detect"
+	updated.Engine.EncodingProfiles = map[string]EncodingProfile{"low": {Bitrate: 500}}
+	updated.Logger.LogLevel = "debug"
+
+	diff := Diff(old, &updated)
+	if want := []string{"engine.detect-ai-url", "engine.encoding-profiles", "logger.log-level"}; !slices.Equal(diff.Applied, want) {
+		t.Fatalf("applied: want %v, got %v", want, diff.Applied)
+	}
+	if want := []string{"server.listen-http-addr"}; !slices.Equal(diff.RequiresRestart, want) {
+		t.Fatalf("requires restart: want %v, got %v", want, diff.RequiresRestart)
+	}
+}
@@ -0,0 +1,245 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var logLevels = []string{"debug", "info", "warn", "error"}
+
+// validator 收集全部配置问题，一次性报告
+type validator struct {
+	errs []error
+}
+
+func (v *validator) addf(key, format string, args ...any) {
+	v.errs = append(v.errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+}
+
+// Validate 校验配置，返回全部问题，每条以 toml 路径开头
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	// server
+	v.checkAddr("server.listen-http-addr", c.Server.ListenHttpAddr)
+	v.checkAddr("server.grpc-peer-addr", c.Server.GrpcPeerAddr)
+	if c.Server.ShutdownTimeout < 0 {
+		v.addf("server.shutdown-timeout", "must not be negative, got %d", c.Server.ShutdownTimeout)
+	}
+	if c.Server.TLS.Enable {
+		v.checkFile("server.tls.cert-file", c.Server.TLS.CertFile, true)
+		v.checkFile("server.tls.key-file", c.Server.TLS.KeyFile, true)
+		v.checkFile("server.tls.client-ca-file", c.Server.TLS.ClientCAFile, false)
+	}
+
+	// engine
+	v.checkURL("engine.detect-ai-url", c.Engine.DetectAIURL, "http", "https")
+	v.checkURL("engine.push-url-internal-pre", c.Engine.PushUrlInternalPre)
+	v.checkURL("engine.push-url-public-pre", c.Engine.PushUrlPublicPre)
+	if c.Engine.HealthyHeartbeat <= 0 {
+		v.addf("engine.healthy-heartbeat", "must be a positive number of seconds, got %d", c.Engine.HealthyHeartbeat)
+	}
+	if c.Engine.CloseChanCap < 0 {
+		v.addf("engine.close-chan-cap", "must not be negative, got %d", c.Engine.CloseChanCap)
+	}
+	if c.Engine.UvicornSocket {
+		if c.Engine.SocketPath == "" {
+			v.addf("engine.socket-path", "required when uvicorn-socket is enabled")
+		} else if info, err := os.Stat(c.Engine.SocketPath); err != nil {
+			v.addf("engine.socket-path", "uvicorn-socket is enabled but socket is not accessible: %v", err)
+		} else if info.Mode()&os.ModeSocket == 0 {
+			v.addf("engine.socket-path", "%s is not a unix socket", c.Engine.SocketPath)
+		}
+	}
+	if err := c.Engine.Encoding.Validate(); err != nil {
+		v.addf("engine.encoding", "%v", err)
+	}
+	for name, profile := range c.Engine.EncodingProfiles {
+		if err := c.Engine.Encoding.Merge(profile).Validate(); err != nil {
+			v.addf("engine.encoding-profiles."+name, "%v", err)
+		}
+	}
+	v.checkQuota(c.Engine.Quota)
+	v.checkLogPath("engine.audit-log", c.Engine.AuditLog)
+	v.checkLogPath("engine.stats-db", c.Engine.StatsDB)
+	for _, field := range []struct {
+		key   string
+		value int
+	}{
+		{"engine.audit-max-size", c.Engine.AuditMaxSize},
+		{"engine.audit-max-age", c.Engine.AuditMaxAge},
+		{"engine.audit-max-backups", c.Engine.AuditMaxBackups},
+	} {
+		if field.value < 0 {
+			v.addf(field.key, "must not be negative, got %d", field.value)
+		}
+	}
+	if c.Engine.StatsRetention < 0 {
+		v.addf("engine.stats-retention", "must not be negative, got %d", c.Engine.StatsRetention)
+	}
+	v.checkLogPath("engine.event-db", c.Engine.EventDB)
+	if c.Engine.EventDB != "" && c.Engine.EventDB == c.Engine.StatsDB {
+		v.addf("engine.event-db", "must not be the same file as engine.stats-db")
+	}
+	if c.Engine.EventRetention < 0 {
+		v.addf("engine.event-retention", "must not be negative, got %d", c.Engine.EventRetention)
+	}
+	if c.Engine.EventWebhook != "" {
+		v.checkURL("engine.event-webhook", c.Engine.EventWebhook, "http", "https")
+	}
+	if c.Engine.Watchdog.StallTimeout < 0 {
+		v.addf("engine.watchdog.stall-timeout", "must not be negative, got %d", c.Engine.Watchdog.StallTimeout)
+	}
+	if c.Engine.Watchdog.MaxRestarts < 0 {
+		v.addf("engine.watchdog.max-restarts", "must not be negative, got %d", c.Engine.Watchdog.MaxRestarts)
+	}
+
+	// logger
+	if !slices.Contains(logLevels, strings.ToLower(c.Logger.LogLevel)) {
+		v.addf("logger.log-level", "must be one of %s, got %q", strings.Join(logLevels, " "), c.Logger.LogLevel)
+	}
+	v.checkLogPath("logger.log-path", c.Logger.LogPath)
+	if c.Logger.Format != "" && c.Logger.Format != LogFormatConsole && c.Logger.Format != LogFormatJSON {
+		v.addf("logger.format", "must be %s or %s, got %q", LogFormatConsole, LogFormatJSON, c.Logger.Format)
+	}
+
+	// tracing
+	if c.Tracing.Enable {
+		if c.Tracing.Exporter != "" && !slices.Contains(TracingExporters, c.Tracing.Exporter) {
+			v.addf("tracing.exporter", "must be one of %s, got %q", strings.Join(TracingExporters, " "), c.Tracing.Exporter)
+		}
+		if c.Tracing.Exporter == TracingExporterFile {
+			if c.Tracing.File == "" {
+				v.addf("tracing.file", "required when exporter is %s", TracingExporterFile)
+			} else {
+				v.checkLogPath("tracing.file", c.Tracing.File)
+			}
+		}
+		if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+			v.addf("tracing.sample-ratio", "must be in [0, 1], got %v", c.Tracing.SampleRatio)
+		}
+	}
+
+	// auth
+	if c.Auth.Enable {
+		if len(c.Auth.APIKeys) == 0 && c.Auth.JWT.Secret == "" {
+			v.addf("auth", "enabled but neither api-keys nor jwt.secret is configured")
+		}
+		for i, key := range c.Auth.APIKeys {
+			if key.Key == "" {
+				v.addf(fmt.Sprintf("auth.api-keys[%d]", i), "key is empty")
+			}
+		}
+	}
+
+	// sessions
+	seen := make(map[string]bool, len(c.Sessions))
+	for i, session := range c.Sessions {
+		key := fmt.Sprintf("sessions[%d]", i)
+		switch {
+		case session.ID == "":
+			v.addf(key+".id", "required")
+		case seen[session.ID]:
+			v.addf(key+".id", "duplicate session id %q", session.ID)
+		}
+		seen[session.ID] = true
+		if session.RtspURL == "" {
+			v.addf(key+".rtsp-url", "required")
+		}
+		if session.EncodingProfile != "" {
+			if _, ok := c.Engine.EncodingProfiles[session.EncodingProfile]; !ok {
+				v.addf(key+".encoding-profile", "profile %q not defined in engine.encoding-profiles", session.EncodingProfile)
+			}
+		}
+	}
+
+	return errors.Join(v.errs...)
+}
+
+func (v *validator) checkAddr(key, addr string) {
+	if addr == "" {
+		v.addf(key, "required, e.g. 0.0.0.0:8080")
+		return
+	}
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		v.addf(key, "invalid address %q: %v", addr, err)
+		return
+	}
+	if n, err := strconv.Atoi(port); err != nil || n < 0 || n > 65535 {
+		v.addf(key, "invalid port %q", port)
+	}
+}
+
+func (v *validator) checkURL(key, raw string, schemes ...string) {
+	if raw == "" {
+		v.addf(key, "required")
+		return
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		v.addf(key, "invalid url: %v", err)
+		return
+	}
+	if u.Scheme == "" || u.Host == "" {
+		v.addf(key, "url must include scheme and host, got %q", raw)
+		return
+	}
+	if len(schemes) > 0 && !slices.Contains(schemes, u.Scheme) {
+		v.addf(key, "url scheme must be one of %s, got %q", strings.Join(schemes, " "), u.Scheme)
+	}
+}
+
+func (v *validator) checkFile(key, path string, required bool) {
+	if path == "" {
+		if required {
+			v.addf(key, "required")
+		}
+		return
+	}
+	if _, err := os.Stat(path); err != nil {
+		v.addf(key, "file not accessible: %v", err)
+	}
+}
+
+// checkLogPath 输出文件所在目录不存在时创建，并确认可写
+func (v *validator) checkLogPath(key, path string) {
+	if path == "" {
+		return
+	}
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		v.addf(key, "cannot create directory: %v", err)
+		return
+	}
+	f, err := os.CreateTemp(dir, ".write-check-*")
+	if err != nil {
+		v.addf(key, "directory not writable: %v", err)
+		return
+	}
+	_ = f.Close()
+	_ = os.Remove(f.Name())
+}
+
+func (v *validator) checkQuota(quota Quota) {
+	check := func(key string, q TenantQuota) {
+		if q.MaxSessions < 0 {
+			v.addf(key+".max-sessions", "must not be negative, got %d", q.MaxSessions)
+		}
+		if q.MaxPixelRate < 0 {
+			v.addf(key+".max-pixel-rate", "must not be negative, got %d", q.MaxPixelRate)
+		}
+	}
+	check("engine.quota", TenantQuota{MaxSessions: quota.MaxSessions, MaxPixelRate: quota.MaxPixelRate})
+	check("engine.quota.tenant", quota.Tenant)
+	for tenant, q := range quota.Tenants {
+		check("engine.quota.tenants."+tenant, q)
+	}
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	valid := func() *Config {
+		cfg := &Config{}
+		cfg.Server.ListenHttpAddr = "0.0.0.0:8080"
+		cfg.Server.GrpcPeerAddr = "0.0.0.0:8081"
+		cfg.Engine.DetectAIURL = "http://ai:5000/detect"
+		cfg.Engine.PushUrlInternalPre = "rtmp://rtmp-server/live/stream"
+		cfg.Engine.PushUrlPublicPre = "rtmp://localhost:1935/live/stream"
+		cfg.Engine.HealthyHeartbeat = 60
+		cfg.Logger.LogLevel = "debug"
+		cfg.Logger.LogPath = filepath.Join(t.TempDir(), "logs", "detectLog")
+		return cfg
+	}
+
+	if err := valid().Validate(); err != nil {
+		t.Fatalf("want valid, got %v", err)
+	}
+
+	cfg := valid()
+	cfg.Server.ListenHttpAddr = "8080"
+	cfg.Engine.DetectAIURL = ""
+	cfg.Engine.HealthyHeartbeat = 0
+	cfg.Engine.UvicornSocket = true
+	cfg.Engine.SocketPath = filepath.Join(t.TempDir(), "missing.sock")
+	cfg.Logger.LogLevel = "verbose"
+	cfg.Sessions = []DeclaredSession{{ID: "cam-1", RtspURL: "rtsp://cam"}, {ID: "cam-1"}}
+	cfg.Engine.EncodingProfiles = map[string]EncodingProfile{
+		"vp8":   {Codec: CodecVP8},
+		"mjpeg": {Codec: CodecMJPEG, CRF: 40},
+		"clear": {Clear: []string{"bogus"}},
+	}
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("want error")
+	}
+	for _, key := range []string{
+		"server.listen-http-addr",
+		"engine.detect-ai-url",
+		"engine.healthy-heartbeat",
+		"engine.socket-path",
+		"logger.log-level",
+		"sessions[1].id",
+		"sessions[1].rtsp-url",
+		"engine.encoding-profiles.vp8",
+		"engine.encoding-profiles.mjpeg",
+		"engine.encoding-profiles.clear",
+	} {
+		if !strings.Contains(err.Error(), key+":") {
+			t.Errorf("missing problem for %s in:\n%v", key, err)
+		}
+	}
+}
+
+func TestEncodingProfileMergeClear(t *testing.T) {
+	base := EncodingProfile{Preset: "veryfast", Tune: "zerolatency", Bitrate: 800, CRF: 23}
+	got := base.Merge(EncodingProfile{CRF: 28, Clear: []string{"tune", "bitrate"}})
+	want := EncodingProfile{Preset: "veryfast", CRF: 28}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %+v, got %+v", want, got)
+	}
+}
@@ -0,0 +1,59 @@
+package engine
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go_client/pkg/auth"
+	"go_client/pkg/logger"
+	"go_client/pkg/result"
+	"net/http"
+)
+
+type AdminHTTPService interface {
+	GetLogLevel(c *gin.Context) error // 获取全局日志等级
+	SetLogLevel(c *gin.Context) error // 运行时修改全局日志等级，重启或配置热加载后以配置文件为准
+}
+
+// RegisterAdminHTTPService 注册管理路由，middlewares 作用于全部管理路由（如认证、管理员校验）
+func RegisterAdminHTTPService(eng *gin.Engine, srv AdminHTTPService, middlewares ...gin.HandlerFunc) {
+	admin := eng.Group("/admin", middlewares...)
+	{
+		admin.GET("/log/level", WrapHandler(srv.GetLogLevel))
+		admin.PUT("/log/level", WrapHandler(srv.SetLogLevel))
+	}
+}
+
+// AdminHTTPServiceV1 管理http服务
+type AdminHTTPServiceV1 struct {
+	logLevel zap.AtomicLevel
+	logger   *zap.Logger
+}
+
+func NewAdminHTTPServiceV1(logLevel zap.AtomicLevel, logger *zap.Logger) AdminHTTPService {
+	return AdminHTTPServiceV1{logLevel, logger}
+}
+
+func (a AdminHTTPServiceV1) GetLogLevel(c *gin.Context) error {
+	result.New[LogLevel](http.StatusOK).
+		Data(LogLevel{Level: a.logLevel.Level().String()}).
+		Ok(c.Writer)
+	return nil
+}
+
+func (a AdminHTTPServiceV1) SetLogLevel(c *gin.Context) error {
+	var req LogLevel
+	if err := Bind(&req, c.Request.Body); err != nil {
+		return err
+	}
+	level := logger.ParseLevel(req.Level)
+	old := a.logLevel.Level()
+	a.logLevel.SetLevel(level)
+	a.logger.Info("log level changed",
+		zap.String("from", old.String()), zap.String("to", level.String()),
+		zap.Stringer("principal", auth.FromContext(c.Request.Context())))
+
+	result.New[LogLevel](http.StatusOK).
+		Data(LogLevel{Level: level.String()}).
+		Ok(c.Writer)
+	return nil
+}
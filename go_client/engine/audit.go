@@ -0,0 +1,259 @@
+package engine
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go.uber.org/zap"
+	"go_client/pkg/auth"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"gopkg.in/natefinch/lumberjack.v2"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// 会话生命周期审计动作
+const (
+	AuditCreated       = "created"        // 创建
+	AuditPrepared      = "prepared"       // 拉流与推流 FFmpeg 启动完成
+	AuditUpdated       = "updated"        // 运行时更新配置
+	AuditDetectStarted = "detect_started" // 开启识别
+	AuditDetectStopped = "detect_stopped" // 暂停识别
+	AuditReconnecting  = "reconnecting"   // 拉流断开重连
+	AuditRestarted     = "restarted"      // 看门狗重启卡住的 FFmpeg
+	AuditFailed        = "failed"         // 异常失败
+	AuditStopped       = "stopped"        // 停止运行
+	AuditRemoved       = "removed"        // 删除
+)
+
+// AuditActorSystem 引擎内部状态转换的调用方
+const AuditActorSystem = "system"
+
+// AuditEvent 会话生命周期审计事件
+type AuditEvent struct {
+	Time      time.Time `json:"time"`
+	SessionID string    `json:"sessionID"`
+	Tenant    string    `json:"tenant,omitempty"`
+	Action    string    `json:"action"`
+	Actor     string    `json:"actor"`            // 调用方：认证方式:标识@租户，未开启认证为 anonymous，内部转换为 system
+	Peer      string    `json:"peer,omitempty"`   // 调用方地址：HTTP 客户端 IP / gRPC peer
+	Reason    string    `json:"reason,omitempty"` // 原因：调用方通过 X-Audit-Reason 提供，或错误信息
+}
+
+// AuditFilter 审计事件查询条件，零值字段不过滤
+type AuditFilter struct {
+	SessionID string
+	Action    string
+	Actor     string
+	From      time.Time
+	To        time.Time
+}
+
+func (f AuditFilter) match(event AuditEvent, principal *auth.Principal) bool {
+	return principal.CanAccess(event.Tenant) &&
+		(f.SessionID == "" || event.SessionID == f.SessionID) &&
+		(f.Action == "" || event.Action == f.Action) &&
+		(f.Actor == "" || event.Actor == f.Actor) &&
+		(f.From.IsZero() || !event.Time.Before(f.From)) &&
+		(f.To.IsZero() || event.Time.Before(f.To))
+}
+
+// auditBackupTimeFormat lumberjack 轮转文件名中的时间格式：<name>-<time><ext>
+const auditBackupTimeFormat = "2006-01-02T15-04-05.000"
+
+// AuditRotation 审计日志轮转配置，MaxSize 为 0 时使用默认 100MB，MaxAge、MaxBackups 为 0 表示不清理
+type AuditRotation struct {
+	MaxSize    int // 单个文件最大大小（MB）
+	MaxAge     int // 轮转文件保留天数
+	MaxBackups int // 轮转文件最大保留数量
+}
+
+// AuditLog 追加写入的本地审计日志，每行一个 JSON 事件，按大小轮转；nil 表示未开启审计
+type AuditLog struct {
+	path   string
+	writer *lumberjack.Logger // 写入与轮转，并发安全
+	logger *zap.Logger
+}
+
+// OpenAuditLog 打开审计日志文件，目录不存在时创建
+func OpenAuditLog(path string, rotation AuditRotation, logger *zap.Logger) (*AuditLog, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("create audit log directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open audit log: %w", err)
+	}
+	_ = file.Close()
+	return &AuditLog{
+		path: path,
+		writer: &lumberjack.Logger{
+			Filename:   path,
+			MaxSize:    rotation.MaxSize,
+			MaxAge:     rotation.MaxAge,
+			MaxBackups: rotation.MaxBackups,
+		},
+		logger: logger,
+	}, nil
+}
+
+// Append 追加审计事件，写入失败仅记录日志，不影响会话操作
+func (a *AuditLog) Append(event AuditEvent) {
+	if a == nil {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	line, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+
+	if _, err := a.writer.Write(append(line, '\n')); err != nil {
+		a.logger.Error("write audit log failed", zap.Error(err), zap.Any("event", event))
+	}
+}
+
+// Query 按条件查询调用方可见的审计事件，按时间倒序，返回当前页与总数；limit 为 0 表示不分页
+func (a *AuditLog) Query(ctx context.Context, filter AuditFilter, offset, limit int) ([]AuditEvent, int, error) {
+	if a == nil {
+		return nil, 0, nil
+	}
+	principal := auth.FromContext(ctx)
+
+	// 使用独立的只读句柄按时间顺序读取轮转文件与当前文件，不阻塞写入
+	events := make([]AuditEvent, 0)
+	for _, path := range append(a.backups(), a.path) {
+		err := readAuditFile(path, func(event AuditEvent) {
+			if filter.match(event, principal) {
+				events = append(events, event)
+			}
+		})
+		if err != nil && !os.IsNotExist(err) {
+			return nil, 0, fmt.Errorf("read audit log: %w", err)
+		}
+	}
+
+	slices.Reverse(events)
+	return paginate(events, offset, limit), len(events), nil
+}
+
+// backups 轮转后的审计日志文件，按轮转时间从早到晚排列
+func (a *AuditLog) backups() []string {
+	dir := filepath.Dir(a.path)
+	ext := filepath.Ext(a.path)
+	prefix := strings.TrimSuffix(filepath.Base(a.path), ext) + "-"
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	backups := make([]string, 0)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+		if _, err := time.Parse(auditBackupTimeFormat, strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext)); err != nil {
+			continue
+		}
+		backups = append(backups, filepath.Join(dir, name))
+	}
+	// 文件名中的时间格式按字典序即时间顺序
+	slices.Sort(backups)
+	return backups
+}
+
+// readAuditFile 逐行读取审计事件，跳过损坏的行（如进程崩溃时写入不完整）
+func readAuditFile(path string, fn func(event AuditEvent)) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var event AuditEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			continue
+		}
+		fn(event)
+	}
+	return scanner.Err()
+}
+
+func (a *AuditLog) Close() error {
+	if a == nil {
+		return nil
+	}
+	return a.writer.Close()
+}
+
+type auditInfoKey struct{}
+
+// auditInfo 审计所需的调用方地址与原因
+type auditInfo struct {
+	peer   string
+	reason string
+}
+
+func withAuditInfo(ctx context.Context, peer, reason string) context.Context {
+	return context.WithValue(ctx, auditInfoKey{}, auditInfo{peer: peer, reason: reason})
+}
+
+// auditInfoFromContext HTTP 请求由 AuditMiddleware 写入，gRPC 请求取 peer 地址与 metadata x-audit-reason
+func auditInfoFromContext(ctx context.Context) auditInfo {
+	if info, ok := ctx.Value(auditInfoKey{}).(auditInfo); ok {
+		return info
+	}
+	var info auditInfo
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		info.peer = p.Addr.String()
+	}
+	if values := metadata.ValueFromIncomingContext(ctx, "x-audit-reason"); len(values) > 0 {
+		info.reason = values[0]
+	}
+	return info
+}
+
+// AuditEvents 查询调用方租户可见的审计事件，未开启审计时返回空列表
+func (s *SessionManager) AuditEvents(ctx context.Context, filter AuditFilter, offset, limit int) ([]AuditEvent, int, error) {
+	return s.auditLog.Query(ctx, filter, offset, limit)
+}
+
+// audit 记录调用方触发的会话生命周期事件，reason 为空时使用调用方提供的原因
+func (s *SessionManager) audit(ctx context.Context, id, tenant, action, reason string) {
+	if s.auditLog == nil {
+		return
+	}
+	info := auditInfoFromContext(ctx)
+	if reason == "" {
+		reason = info.reason
+	}
+	s.auditLog.Append(AuditEvent{
+		SessionID: id,
+		Tenant:    tenant,
+		Action:    action,
+		Actor:     auth.FromContext(ctx).String(),
+		Peer:      info.peer,
+		Reason:    reason,
+	})
+}
+
+// audit 记录引擎内部的会话状态转换
+func (s *Session) audit(action, reason string) {
+	s.auditLog.Append(AuditEvent{
+		SessionID: s.id,
+		Tenant:    s.tenant,
+		Action:    action,
+		Actor:     AuditActorSystem,
+		Reason:    reason,
+	})
+}
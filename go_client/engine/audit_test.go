@@ -0,0 +1,63 @@
+package engine
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"go_client/pkg/auth"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAuditLogQuery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	auditLog, err := OpenAuditLog(path, AuditRotation{}, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	auditLog.Append(AuditEvent{Time: start, SessionID: "cam-12", Tenant: "t1", Action: AuditCreated, Actor: "api-key:ops@t1"})
+	auditLog.Append(AuditEvent{Time: start.Add(time.Minute), SessionID: "cam-12", Tenant: "t1", Action: AuditStopped, Actor: "jwt:alice@t1", Reason: "maintenance"})
+	auditLog.Append(AuditEvent{Time: start.Add(2 * time.Minute), SessionID: "cam-13", Tenant: "t2", Action: AuditCreated, Actor: "api-key:ops@t2"})
+	if err := auditLog.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 重新打开后仍可查询
+	auditLog, err = OpenAuditLog(path, AuditRotation{}, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer auditLog.Close()
+
+	events, total, err := auditLog.Query(context.Background(), AuditFilter{SessionID: "cam-12"}, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 2 || events[0].Action != AuditStopped || events[0].Actor != "jwt:alice@t1" {
+		t.Fatalf("unexpected history: total=%d events=%+v", total, events)
+	}
+
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Tenant: "t2"})
+	if _, total, _ := auditLog.Query(ctx, AuditFilter{}, 0, 0); total != 1 {
+		t.Fatalf("tenant t2 should only see its own events, got %d", total)
+	}
+
+	_, total, _ = auditLog.Query(context.Background(), AuditFilter{From: start.Add(30 * time.Second), To: start.Add(90 * time.Second)}, 0, 0)
+	if total != 1 {
+		t.Fatalf("time range filter: got %d events, want 1", total)
+	}
+
+	// 轮转后的文件仍可查询
+	if err := auditLog.writer.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+	auditLog.Append(AuditEvent{Time: start.Add(3 * time.Minute), SessionID: "cam-12", Tenant: "t1", Action: AuditRemoved, Actor: "api-key:ops@t1"})
+	events, total, err = auditLog.Query(context.Background(), AuditFilter{SessionID: "cam-12"}, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 3 || events[0].Action != AuditRemoved || events[2].Action != AuditCreated {
+		t.Fatalf("unexpected history after rotation: total=%d events=%+v", total, events)
+	}
+}
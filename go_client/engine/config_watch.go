@@ -0,0 +1,52 @@
+package engine
+
+import (
+	"fmt"
+	"go.uber.org/zap"
+	"go_client/config"
+	"go_client/pkg/fswatch"
+	"go_client/pkg/logger"
+)
+
+// watchConfig 监听配置文件变化并重新加载
+func (e *DetectionEngine) watchConfig() error {
+	return fswatch.Watch(e.ctx, []string{e.cfg.Path()}, e.ReloadConfig, func(err error) {
+		e.logger.Warn("config watcher error", zap.Error(err))
+	})
+}
+
+// Reload 重新加载配置文件（SIGHUP），返回变化的配置项
+func (e *DetectionEngine) Reload() (config.ReloadDiff, error) {
+	e.reloadMu.Lock()
+	defer e.reloadMu.Unlock()
+
+	current := e.manager.Config()
+	cfg, err := current.Reload()
+	if err != nil {
+		return config.ReloadDiff{}, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return config.ReloadDiff{}, fmt.Errorf("invalid config:\n%w", err)
+	}
+
+	diff := config.Diff(current, cfg)
+	if err := e.manager.ApplyConfig(cfg); err != nil {
+		return config.ReloadDiff{}, fmt.Errorf("invalid config: %w", err)
+	}
+	e.logLevel.SetLevel(logger.ParseLevel(cfg.Logger.LogLevel))
+	e.manager.SetDeclaredSessions(cfg.Sessions)
+	return diff, nil
+}
+
+// ReloadConfig 重新加载配置文件并记录变化，解析失败时保留当前配置
+func (e *DetectionEngine) ReloadConfig() {
+	diff, err := e.Reload()
+	if err != nil {
+		e.logger.Warn("config reload failed, keep current config", zap.Error(err))
+		return
+	}
+	e.logger.Info("🔄 config reloaded", zap.Strings("applied", diff.Applied))
+	if len(diff.RequiresRestart) > 0 {
+		e.logger.Warn("⚠️ config changes require restart to take effect", zap.Strings("fields", diff.RequiresRestart))
+	}
+}
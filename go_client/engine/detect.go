@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"go_client/pkg/tracing"
 	"io"
 	"net"
 	"net/http"
@@ -29,6 +30,12 @@ type DetectResponse struct {
 	Error   string            `json:"error"`
 }
 
+// detectFrame 待识别帧
+type detectFrame struct {
+	id   uint64 // 会话内帧序号
+	data []byte // JPEG 编码图像
+}
+
 var bufPool sync.Pool
 
 func init() {
@@ -36,7 +43,7 @@ func init() {
 }
 
 // 监测对象
-func detectObjects(data []byte, aiURL string) ([]DetectionResult, error) {
+func detectObjects(ctx context.Context, data []byte, aiURL string) ([]DetectionResult, error) {
 	if data == nil || len(data) == 0 {
 		return nil, fmt.Errorf("图像识别 输入数据为空")
 	}
@@ -47,7 +54,14 @@ func detectObjects(data []byte, aiURL string) ([]DetectionResult, error) {
 	buf.Reset()
 	buf.Write(data)
 
-	resp, err := http.Post(aiURL, "application/octet-stream", buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, aiURL, buf)
+	if err != nil {
+		return nil, fmt.Errorf("图像识别 请求创建失败: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	tracing.Inject(ctx, req.Header)
+
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("图像识别 请求失败: %w", err)
 	}
@@ -76,7 +90,7 @@ func detectObjects(data []byte, aiURL string) ([]DetectionResult, error) {
 }
 
 // detectObjectsUvicronSocket 开启 UvicronSocket 减少 tcp损耗
-func detectObjectsUvicronSocket(data []byte, socketPath, aiURL string) ([]DetectionResult, error) {
+func detectObjectsUvicronSocket(ctx context.Context, data []byte, socketPath, aiURL string) ([]DetectionResult, error) {
 	buf := bufPool.Get().(*bytes.Buffer)
 	defer bufPool.Put(buf)
 	buf.Reset()
@@ -92,11 +106,12 @@ func detectObjectsUvicronSocket(data []byte, socketPath, aiURL string) ([]Detect
 		Timeout: 5 * time.Second, // 超时控制，可调
 	}
 
-	req, err := http.NewRequest("POST", aiURL, buf)
+	req, err := http.NewRequestWithContext(ctx, "POST", aiURL, buf)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/octet-stream")
+	tracing.Inject(ctx, req.Header)
 
 	resp, err := client.Do(req)
 	if err != nil {
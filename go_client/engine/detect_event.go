@@ -0,0 +1,46 @@
+package engine
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// DetectionEvent 识别事件，每次识别完成后产生，携带会话名称、站点、标签与元数据，下游无需再查询会话
+type DetectionEvent struct {
+	SessionID string            `json:"sessionID"`
+	Tenant    string            `json:"tenant,omitempty"`
+	Name      string            `json:"name,omitempty"`
+	Site      string            `json:"site,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Metadata  json.RawMessage   `json:"metadata,omitempty"`
+	Time      time.Time         `json:"time"`
+	Results   []DetectionResult `json:"results"`
+}
+
+// DetectionEventHandler 识别事件处理函数，在识别 goroutine 中同步调用，不应阻塞
+type DetectionEventHandler func(event DetectionEvent)
+
+// newDetectionEvent 生成携带会话信息的识别事件
+func (s *Session) newDetectionEvent(results []DetectionResult) DetectionEvent {
+	return DetectionEvent{
+		SessionID: s.id,
+		Tenant:    s.tenant,
+		Name:      s.name,
+		Site:      s.site,
+		Labels:    s.labels,
+		Metadata:  s.metadata,
+		Time:      time.Now(),
+		Results:   results,
+	}
+}
+
+// emitDetection 分发识别事件
+func (s *Session) emitDetection(results []DetectionResult) {
+	if len(s.eventHandlers) == 0 {
+		return
+	}
+	event := s.newDetectionEvent(results)
+	for _, handler := range s.eventHandlers {
+		handler(event)
+	}
+}
@@ -0,0 +1,389 @@
+package engine
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	bolt "go.etcd.io/bbolt"
+	"go.uber.org/zap"
+	"go_client/pkg/auth"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 识别统计时间桶粒度
+const (
+	StatsBucketMinute = "minute"
+	StatsBucketHour   = "hour"
+	StatsBucketDay    = "day"
+)
+
+const (
+	statsFlushInterval = 10 * time.Second // 内存中的统计写入存储的间隔
+	statsPurgeInterval = time.Hour        // 清理过期统计的间隔
+)
+
+var statsRootBucket = []byte("tenant-stats") // 租户 + 会话 ID -> 每分钟统计
+
+// DetectStats 识别统计时间桶
+type DetectStats struct {
+	Start  time.Time             `json:"start"`  // 时间桶起始时间
+	Frames int64                 `json:"frames"` // 识别帧数
+	Peak   int                   `json:"peak"`   // 单帧最大目标数
+	Labels map[string]LabelStats `json:"labels"` // 按类别统计
+}
+
+// LabelStats 单个类别的识别统计
+type LabelStats struct {
+	Count         int64   `json:"count"`         // 识别目标累计数（逐帧累加）
+	Peak          int     `json:"peak"`          // 单帧最大目标数
+	AvgConfidence float64 `json:"avgConfidence"` // 平均置信度
+}
+
+// statsRecord 每会话每分钟的统计记录，可合并为更大的时间桶
+type statsRecord struct {
+	Frames int64                   `json:"f"`
+	Peak   int                     `json:"p"`
+	Labels map[string]*labelRecord `json:"l,omitempty"`
+}
+
+type labelRecord struct {
+	Count   int64   `json:"c"`
+	Peak    int     `json:"p"`
+	ConfSum float64 `json:"s"`
+}
+
+// add 累加一帧的识别结果
+func (r *statsRecord) add(results []DetectionResult) {
+	r.Frames++
+	r.Peak = max(r.Peak, len(results))
+	frame := make(map[string]int)
+	for _, result := range results {
+		frame[result.Label]++
+		label := r.label(result.Label)
+		label.Count++
+		label.ConfSum += result.Conf
+	}
+	for name, count := range frame {
+		label := r.label(name)
+		label.Peak = max(label.Peak, count)
+	}
+}
+
+func (r *statsRecord) label(name string) *labelRecord {
+	if r.Labels == nil {
+		r.Labels = make(map[string]*labelRecord)
+	}
+	label, ok := r.Labels[name]
+	if !ok {
+		label = &labelRecord{}
+		r.Labels[name] = label
+	}
+	return label
+}
+
+// merge 合并统计记录，计数累加，峰值取最大
+func (r *statsRecord) merge(other *statsRecord) {
+	r.Frames += other.Frames
+	r.Peak = max(r.Peak, other.Peak)
+	for name, o := range other.Labels {
+		label := r.label(name)
+		label.Count += o.Count
+		label.Peak = max(label.Peak, o.Peak)
+		label.ConfSum += o.ConfSum
+	}
+}
+
+func (r *statsRecord) stats(start time.Time) DetectStats {
+	stats := DetectStats{Start: start, Frames: r.Frames, Peak: r.Peak, Labels: make(map[string]LabelStats, len(r.Labels))}
+	for name, label := range r.Labels {
+		var avg float64
+		if label.Count > 0 {
+			avg = label.ConfSum / float64(label.Count)
+		}
+		stats.Labels[name] = LabelStats{Count: label.Count, Peak: label.Peak, AvgConfidence: avg}
+	}
+	return stats
+}
+
+// statsBucketStart 时间桶起始时间，小时与天按 loc 时区对齐
+func statsBucketStart(t time.Time, bucket string, loc *time.Location) time.Time {
+	t = t.In(loc)
+	switch bucket {
+	case StatsBucketMinute:
+		return t.Truncate(time.Minute)
+	case StatsBucketDay:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, loc)
+	}
+}
+
+type statsKey struct {
+	tenant    string
+	sessionID string
+	minute    int64 // Unix 秒，按分钟对齐
+}
+
+// statsSessionBucket 会话统计 bucket 名：租户与会话 ID 以 \x00 分隔，同一 ID 被不同租户先后使用时统计互不影响
+func statsSessionBucket(tenant, sessionID string) []byte {
+	return []byte(tenant + "\x00" + sessionID)
+}
+
+func parseStatsSessionBucket(name []byte) (tenant, sessionID string, ok bool) {
+	return strings.Cut(string(name), "\x00")
+}
+
+func statsMinuteKey(minute int64) []byte {
+	return binary.BigEndian.AppendUint64(nil, uint64(minute))
+}
+
+// DetectStatsStore 识别统计存储：按会话每分钟聚合，定期合并写入本地 bbolt 数据库；nil 表示未开启统计
+type DetectStatsStore struct {
+	db        *bolt.DB
+	retention time.Duration // 统计保留时长，0 表示永久保留
+	logger    *zap.Logger
+
+	mu      sync.Mutex
+	pending map[statsKey]*statsRecord // 尚未写入存储的统计
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// OpenDetectStatsStore 打开识别统计数据库，目录不存在时创建
+func OpenDetectStatsStore(path string, retention time.Duration, logger *zap.Logger) (*DetectStatsStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("create stats db directory: %w", err)
+	}
+	db, err := bolt.Open(path, 0o644, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("open stats db: %w", err)
+	}
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(statsRootBucket)
+		return err
+	}); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("init stats db: %w", err)
+	}
+
+	store := &DetectStatsStore{
+		db:        db,
+		retention: retention,
+		logger:    logger,
+		pending:   make(map[statsKey]*statsRecord),
+		done:      make(chan struct{}),
+	}
+	store.wg.Add(1)
+	go store.loop()
+	return store, nil
+}
+
+// Record 累加识别事件，作为 DetectionEventHandler 在识别 goroutine 中调用，仅写内存
+func (d *DetectStatsStore) Record(event DetectionEvent) {
+	if d == nil {
+		return
+	}
+	key := statsKey{tenant: event.Tenant, sessionID: event.SessionID, minute: event.Time.Truncate(time.Minute).Unix()}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	record, ok := d.pending[key]
+	if !ok {
+		record = &statsRecord{}
+		d.pending[key] = record
+	}
+	record.add(event.Results)
+}
+
+func (d *DetectStatsStore) loop() {
+	defer d.wg.Done()
+	ticker := time.NewTicker(statsFlushInterval)
+	defer ticker.Stop()
+	lastPurge := time.Now()
+	for {
+		select {
+		case <-d.done:
+			return
+		case now := <-ticker.C:
+			if err := d.flush(); err != nil {
+				d.logger.Error("flush detect stats failed", zap.Error(err))
+			}
+			if d.retention > 0 && now.Sub(lastPurge) >= statsPurgeInterval {
+				lastPurge = now
+				if err := d.purge(now.Add(-d.retention)); err != nil {
+					d.logger.Error("purge detect stats failed", zap.Error(err))
+				}
+			}
+		}
+	}
+}
+
+// flush 将内存中的统计合并写入存储
+func (d *DetectStatsStore) flush() error {
+	d.mu.Lock()
+	pending := d.pending
+	d.pending = make(map[statsKey]*statsRecord)
+	d.mu.Unlock()
+	if len(pending) == 0 {
+		return nil
+	}
+
+	return d.db.Update(func(tx *bolt.Tx) error {
+		root := tx.Bucket(statsRootBucket)
+		for key, record := range pending {
+			bucket, err := root.CreateBucketIfNotExists(statsSessionBucket(key.tenant, key.sessionID))
+			if err != nil {
+				return err
+			}
+			k := statsMinuteKey(key.minute)
+			if data := bucket.Get(k); data != nil {
+				var stored statsRecord
+				if err := json.Unmarshal(data, &stored); err == nil {
+					stored.merge(record)
+					record = &stored
+				}
+			}
+			data, err := json.Marshal(record)
+			if err != nil {
+				return err
+			}
+			if err := bucket.Put(k, data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// purge 删除 cutoff 之前的统计，会话没有剩余统计时一并删除
+func (d *DetectStatsStore) purge(cutoff time.Time) error {
+	end := statsMinuteKey(cutoff.Unix())
+	return d.db.Update(func(tx *bolt.Tx) error {
+		root := tx.Bucket(statsRootBucket)
+		var empty [][]byte
+		err := root.ForEachBucket(func(name []byte) error {
+			bucket := root.Bucket(name)
+			var expired [][]byte
+			c := bucket.Cursor()
+			for k, _ := c.First(); k != nil && string(k) < string(end); k, _ = c.Next() {
+				expired = append(expired, k)
+			}
+			for _, k := range expired {
+				if err := bucket.Delete(k); err != nil {
+					return err
+				}
+			}
+			if k, _ := bucket.Cursor().First(); k == nil {
+				empty = append(empty, name)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, name := range empty {
+			if err := root.DeleteBucket(name); err != nil && !errors.Is(err, bolt.ErrBucketNotFound) {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Query 查询会话在 [from, to) 内调用方可见的统计，按 bucket 粒度合并，返回有数据的时间桶（升序）与区间合计；
+// 调用方可见的统计中没有该会话时返回 ErrSessionNotExists
+func (d *DetectStatsStore) Query(ctx context.Context, sessionID string, from, to time.Time, bucket string, loc *time.Location) ([]DetectStats, DetectStats, error) {
+	if d == nil {
+		return []DetectStats{}, DetectStats{Start: from, Labels: map[string]LabelStats{}}, nil
+	}
+	if err := d.flush(); err != nil {
+		return nil, DetectStats{}, fmt.Errorf("flush detect stats: %w", err)
+	}
+	principal := auth.FromContext(ctx)
+
+	// 管理员可见该会话 ID 下所有租户的统计，按分钟合并
+	minutes := make(map[int64]*statsRecord)
+	found := false
+	err := d.db.View(func(tx *bolt.Tx) error {
+		root := tx.Bucket(statsRootBucket)
+		begin, end := statsMinuteKey(from.Truncate(time.Minute).Unix()), statsMinuteKey(to.Unix())
+		return root.ForEachBucket(func(name []byte) error {
+			tenant, id, ok := parseStatsSessionBucket(name)
+			if !ok || id != sessionID || !principal.CanAccess(tenant) {
+				return nil
+			}
+			found = true
+			c := root.Bucket(name).Cursor()
+			for k, v := c.Seek(begin); k != nil && string(k) < string(end); k, v = c.Next() {
+				var record statsRecord
+				if err := json.Unmarshal(v, &record); err != nil {
+					continue
+				}
+				minute := int64(binary.BigEndian.Uint64(k))
+				if stored, ok := minutes[minute]; ok {
+					stored.merge(&record)
+				} else {
+					minutes[minute] = &record
+				}
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, DetectStats{}, fmt.Errorf("read detect stats: %w", err)
+	}
+	if !found {
+		return nil, DetectStats{}, fmt.Errorf("%w: %s", ErrSessionNotExists, sessionID)
+	}
+
+	var (
+		starts  []time.Time
+		records []*statsRecord
+		total   statsRecord
+	)
+	for _, minute := range slices.Sorted(maps.Keys(minutes)) {
+		start := statsBucketStart(time.Unix(minute, 0), bucket, loc)
+		if n := len(starts); n == 0 || !starts[n-1].Equal(start) {
+			starts = append(starts, start)
+			records = append(records, &statsRecord{})
+		}
+		records[len(records)-1].merge(minutes[minute])
+		total.merge(minutes[minute])
+	}
+
+	stats := make([]DetectStats, len(records))
+	for i, record := range records {
+		stats[i] = record.stats(starts[i])
+	}
+	return stats, total.stats(from.In(loc)), nil
+}
+
+// Close 停止后台写入，写入剩余统计并关闭数据库
+func (d *DetectStatsStore) Close() error {
+	if d == nil {
+		return nil
+	}
+	close(d.done)
+	d.wg.Wait()
+	return errors.Join(d.flush(), d.db.Close())
+}
+
+// DetectStats 查询会话识别统计，未开启统计或运行中的会话尚无统计时返回空结果；会话删除后仍可查询
+func (s *SessionManager) DetectStats(ctx context.Context, id string, from, to time.Time, bucket string, loc *time.Location) ([]DetectStats, DetectStats, error) {
+	stats, total, err := s.detectStats.Query(ctx, id, from, to, bucket, loc)
+	if errors.Is(err, ErrSessionNotExists) {
+		if _, exists := s.loadSession(ctx, id); exists {
+			return []DetectStats{}, DetectStats{Start: from.In(loc), Labels: map[string]LabelStats{}}, nil
+		}
+	}
+	return stats, total, err
+}
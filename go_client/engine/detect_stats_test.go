@@ -0,0 +1,91 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"go_client/pkg/auth"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDetectStatsStoreQuery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stats.db")
+	store, err := OpenDetectStatsStore(path, 0, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	person := DetectionResult{Label: "person", Conf: 0.8}
+	car := DetectionResult{Label: "car", Conf: 0.6}
+	store.Record(DetectionEvent{SessionID: "cam-12", Tenant: "t1", Time: start, Results: []DetectionResult{person, person, car}})
+	store.Record(DetectionEvent{SessionID: "cam-12", Tenant: "t1", Time: start.Add(10 * time.Second), Results: []DetectionResult{{Label: "person", Conf: 0.6}}})
+	store.Record(DetectionEvent{SessionID: "cam-12", Tenant: "t1", Time: start.Add(90 * time.Minute), Results: nil})
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 重新打开后写入的统计与已存储的同一分钟合并
+	store, err = OpenDetectStatsStore(path, 0, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	store.Record(DetectionEvent{SessionID: "cam-12", Tenant: "t1", Time: start.Add(20 * time.Second), Results: []DetectionResult{car}})
+
+	ctx := context.Background()
+	buckets, total, err := store.Query(ctx, "cam-12", start, start.Add(2*time.Hour), StatsBucketHour, time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(buckets) != 2 || !buckets[1].Start.Equal(start.Add(time.Hour)) {
+		t.Fatalf("unexpected buckets: %+v", buckets)
+	}
+	first := buckets[0]
+	if first.Frames != 3 || first.Peak != 3 {
+		t.Fatalf("frames=%d peak=%d, want 3 3", first.Frames, first.Peak)
+	}
+	if got := first.Labels["person"]; got.Count != 3 || got.Peak != 2 || got.AvgConfidence < 0.733 || got.AvgConfidence > 0.734 {
+		t.Fatalf("unexpected person stats: %+v", got)
+	}
+	if got := first.Labels["car"]; got.Count != 2 || got.Peak != 1 {
+		t.Fatalf("unexpected car stats: %+v", got)
+	}
+	if total.Frames != 4 {
+		t.Fatalf("total frames=%d, want 4", total.Frames)
+	}
+
+	minutes, _, _ := store.Query(ctx, "cam-12", start, start.Add(time.Hour), StatsBucketMinute, time.UTC)
+	if len(minutes) != 1 {
+		t.Fatalf("minute buckets: got %d, want 1", len(minutes))
+	}
+
+	other := auth.WithPrincipal(ctx, &auth.Principal{Tenant: "t2"})
+	if buckets, _, err := store.Query(other, "cam-12", start, start.Add(2*time.Hour), StatsBucketHour, time.UTC); !errors.Is(err, ErrSessionNotExists) {
+		t.Fatalf("tenant t2 should not see stats of t1, got %+v %v", buckets, err)
+	}
+	if _, _, err := store.Query(ctx, "cam-99", start, start.Add(2*time.Hour), StatsBucketHour, time.UTC); !errors.Is(err, ErrSessionNotExists) {
+		t.Fatalf("want ErrSessionNotExists for unknown session, got %v", err)
+	}
+
+	// 同一会话 ID 被另一租户使用时统计按租户分开
+	store.Record(DetectionEvent{SessionID: "cam-12", Tenant: "t2", Time: start.Add(30 * time.Second), Results: []DetectionResult{car}})
+	if _, total, _ := store.Query(other, "cam-12", start, start.Add(2*time.Hour), StatsBucketHour, time.UTC); total.Frames != 1 {
+		t.Fatalf("tenant t2 frames=%d, want 1", total.Frames)
+	}
+	t1 := auth.WithPrincipal(ctx, &auth.Principal{Tenant: "t1"})
+	if _, total, _ := store.Query(t1, "cam-12", start, start.Add(2*time.Hour), StatsBucketHour, time.UTC); total.Frames != 4 {
+		t.Fatalf("tenant t1 frames=%d, want 4", total.Frames)
+	}
+	if _, total, _ := store.Query(ctx, "cam-12", start, start.Add(2*time.Hour), StatsBucketHour, time.UTC); total.Frames != 5 {
+		t.Fatalf("admin frames=%d, want 5", total.Frames)
+	}
+
+	if err := store.purge(start.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if buckets, _, _ := store.Query(t1, "cam-12", start, start.Add(2*time.Hour), StatsBucketHour, time.UTC); len(buckets) != 1 {
+		t.Fatalf("after purge: got %d buckets, want 1", len(buckets))
+	}
+}
@@ -2,6 +2,7 @@ package engine
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"gocv.io/x/gocv"
 	"image"
@@ -124,7 +125,7 @@ func runStream(rtsp string) error {
 	framerate := int(configv1.Framerate)
 
 	// 启动拉流 ffmpeg（拉 RTSP → pipe）
-	cmd, stdout, err := startFFmpegReader(rtsp, width, height, framerate)
+	cmd, stdout, err := startFFmpegReader(rtsp, width, height, framerate, false, nil)
 	if err != nil {
 		return fmt.Errorf("FFmpeg 拉流失败: %w", err)
 	}
@@ -170,7 +171,7 @@ func runStream(rtsp string) error {
 			continue
 		}
 		ai := currentAI.Load().(string)
-		results, err := detectObjects(buf.GetBytes(), ai)
+		results, err := detectObjects(context.Background(), buf.GetBytes(), ai)
 		buf.Close()
 		if err != nil {
 			loggerV1.Error("AI 识别失败", err)
@@ -244,7 +245,7 @@ func controlHTTP() {
 		}
 		defer buf.Close()
 
-		results, err := detectObjects(buf.GetBytes(), configv1.DefaultAIURL)
+		results, err := detectObjects(context.Background(), buf.GetBytes(), configv1.DefaultAIURL)
 		if err != nil {
 			http.Error(w, "AI 识别失败: "+err.Error(), http.StatusInternalServerError)
 			return
@@ -1,7 +1,9 @@
 package engine
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/locales/zh"
@@ -9,9 +11,11 @@ import (
 	"github.com/go-playground/validator/v10"
 	zhTrans "github.com/go-playground/validator/v10/translations/zh"
 	jsoniter "github.com/json-iterator/go"
+	"go_client/config"
 	"go_client/pkg/status"
 	"io"
 	"net/http"
+	"time"
 )
 
 var (
@@ -31,6 +35,10 @@ func init() {
 	if err := zhTrans.RegisterDefaultTranslations(validate, trans); err != nil {
 		panic(err)
 	}
+	// 默认翻译"为禁填字段"无法说明原因，改为提示需同时指定的字段
+	if err := validate.RegisterTranslation("excluded_without", trans, registrationFunc("excluded_without", "{0}需与{1}同时指定", true), translateFunc); err != nil {
+		panic(err)
+	}
 }
 
 func registrationFunc(tag string, translation string, override bool) validator.RegisterTranslationsFunc {
@@ -40,7 +48,7 @@ func registrationFunc(tag string, translation string, override bool) validator.R
 }
 
 func translateFunc(ut ut.Translator, fe validator.FieldError) string {
-	t, err := ut.T(fe.Tag(), fe.Field())
+	t, err := ut.T(fe.Tag(), fe.Field(), fe.Param())
 	if err != nil {
 		return fe.(error).Error()
 	}
@@ -91,12 +99,8 @@ func BindParams[T any](val *T, params gin.Params) error {
 }
 
 type Paging struct {
-	Offset uint `json:"offset" form:"offset" validate:"gte=0"`
-	Limit  uint `json:"limit" form:"limit" validate:"gte=5,lte=20"`
-}
-
-func (r Paging) SumOffset() int {
-	return int(r.Offset * r.Limit)
+	Offset uint `json:"offset" form:"offset" validate:"excluded_without=Limit"` // 跳过的条数，需同时指定 limit
+	Limit  uint `json:"limit" form:"limit" validate:"omitempty,gte=5,lte=20"`   // 0 表示不分页
 }
 
 type PagingAck[T any] struct {
@@ -112,13 +116,193 @@ type SessionAction struct {
 type CreateSessionReq struct {
 	ID         string `json:"id" validate:"required"`
 	RtspURL    string `json:"rtspURL"  validate:"required"` // 摄像头播放地址URL
-	Width      int    `json:"width" validate:"gte=0"`       //  宽
-	Height     int    `json:"height" validate:"gte=0"`      //  高
+	Width      int    `json:"width" validate:"gte=0"`       //  宽，为 0 使用默认值 1280
+	Height     int    `json:"height" validate:"gte=0"`      //  高，为 0 使用默认值 720
 	RetryTimes int    `json:"retryTimes" validate:"gt=0"`   // 读帧失败重试次数
-	Framerate  int    `json:"framerate" validate:"gte=0"`   // 帧率
+	Framerate  int    `json:"framerate" validate:"gte=0"`   // 帧率，为 0 使用默认值 25
+
+	EncodingProfile string                  `json:"encodingProfile"` // 命名编码配置，为空使用默认编码配置
+	Encoding        *config.EncodingProfile `json:"encoding"`        // 编码配置覆盖项，零值字段沿用基础配置
+
+	Name     string            `json:"name" validate:"max=128"`                      // 展示名称
+	Site     string            `json:"site" validate:"max=128"`                      // 站点/位置，可用于列表过滤
+	Labels   map[string]string `json:"labels" validate:"dive,keys,required,endkeys"` // 标签，可用于列表过滤
+	Metadata json.RawMessage   `json:"metadata" validate:"max=65536"`                // 自定义元数据 JSON，随识别事件下发
+
+	Schedule *DetectSchedule `json:"schedule"` // 识别时间计划，为空表示不按计划切换识别状态
+	Counting *LineCounting   `json:"counting"` // 虚拟线进出计数，为空表示不计数
+}
+
+// Options 转换为会话配置项，encoding 为已解析的编码配置
+func (r CreateSessionReq) Options(encoding config.EncodingProfile) []SetSessionOption {
+	return []SetSessionOption{
+		SetSessionVideoStreamConfig(r.Width, r.Height, r.Framerate),
+		SetSessionRetryTimes(r.RetryTimes),
+		SetSessionEncoding(encoding),
+		SetSessionName(r.Name),
+		SetSessionSite(r.Site),
+		SetSessionLabels(r.Labels),
+		SetSessionMetadata(r.Metadata),
+		SetSessionSchedule(r.Schedule),
+		SetSessionLineCounting(r.Counting),
+	}
+}
+
+// 批量创建会话Req，也可通过 text/csv 上传摄像头清单
+type BulkCreateSessionReq struct {
+	Sessions []CreateSessionReq `json:"sessions" validate:"required,min=1,max=500"` // 单项校验失败只影响该项
+}
+
+// 批量操作选择器，按 ID 列表与标签选择会话，结果取并集
+type BulkSelectorReq struct {
+	IDs    []string `json:"ids" validate:"required_without=Labels,max=500"`
+	Labels []string `json:"labels" validate:"required_without=IDs"` // 标签 key 或 key=value，需全部匹配
+}
+
+// 会话列表Req
+type ListSessionReq struct {
+	Paging
+	Status       string   `json:"status" form:"status" validate:"omitempty,oneof=preparing running reconnecting failed stopped"` // 会话状态
+	Site         string   `json:"site" form:"site"`                                                                              // 站点
+	DetectStatus *bool    `json:"detectStatus" form:"detectStatus"`                                                              // 识别状态
+	Labels       []string `json:"label" form:"label"`                                                                            // 标签 key 或 key=value，可重复，需全部匹配
+	Query        string   `json:"q" form:"q"`                                                                                    // ID、名称或拉流地址子串
+	Sort         string   `json:"sort" form:"sort" validate:"omitempty,oneof=id -id state -state uptime -uptime"`                // 排序字段，- 前缀表示降序，默认 id
+}
+
+func (r ListSessionReq) SessionFilter() SessionFilter {
+	return SessionFilter{
+		State:        r.Status,
+		Site:         r.Site,
+		DetectStatus: r.DetectStatus,
+		Labels:       r.Labels,
+		Query:        r.Query,
+	}
+}
+
+// 更新会话Req 未传字段不修改
+type UpdateSessionReq struct {
+	RtspURL      *string `json:"rtspURL" validate:"omitempty,min=1"`   // 摄像头播放地址URL，修改后重启拉流
+	Width        *int    `json:"width" validate:"omitempty,gt=0"`      //  宽，修改后重启拉流与推流
+	Height       *int    `json:"height" validate:"omitempty,gt=0"`     //  高，修改后重启拉流与推流
+	Framerate    *int    `json:"framerate" validate:"omitempty,gt=0"`  // 帧率，修改后重启拉流与推流
+	DetectAIURL  *string `json:"detectAIURL" validate:"omitempty,url"` // 识别请求URL，热更新
+	DetectStatus *bool   `json:"detectStatus"`                         // 识别状态，热更新
+	Debug        *bool   `json:"debug"`                                // 调试模式，仅影响该会话，修改后重启拉流与推流
+
+	EncodingProfile *string                 `json:"encodingProfile"` // 命名编码配置，修改后重启推流
+	Encoding        *config.EncodingProfile `json:"encoding"`        // 编码配置覆盖项，修改后重启推流
+
+	Schedule *DetectSchedule `json:"schedule"` // 识别时间计划，热更新，传空对象表示取消计划
+	Counting *LineCounting   `json:"counting"` // 虚拟线进出计数，热更新并清零计数，传空对象表示关闭
+}
+
+func (r UpdateSessionReq) SessionUpdate() SessionUpdate {
+	return SessionUpdate{
+		RtspURL:      r.RtspURL,
+		Width:        r.Width,
+		Height:       r.Height,
+		Framerate:    r.Framerate,
+		AIURL:        r.DetectAIURL,
+		DetectStatus: r.DetectStatus,
+		Debug:        r.Debug,
+
+		EncodingProfile: r.EncodingProfile,
+		Encoding:        r.Encoding,
+
+		Schedule: r.Schedule,
+		Counting: r.Counting,
+	}
+}
+
+// 识别时间计划手动覆盖Req
+type ScheduleOverrideReq struct {
+	Detect bool      `json:"detect"` // 覆盖期间的识别状态
+	Until  time.Time `json:"until"`  // 覆盖截止时间 RFC3339，须晚于当前时间，为空表示到计划的下一次切换为止
+}
+
+// 获取 FFmpeg 日志Req
+type GetFFmpegLogsReq struct {
+	Source string `json:"source" form:"source" validate:"omitempty,oneof=pull push"` // 日志来源 pull 拉流 push 推流，为空表示全部
+	Limit  int    `json:"limit" form:"limit" validate:"gte=0,lte=256"`               // 返回最近的行数，0 表示全部
 }
 
 type GetSessionDescByIDResp struct {
 	Exists  bool        `json:"exists"`
 	Session SessionDesc `json:"desc"`
 }
+
+// 审计事件查询Req
+type AuditQueryReq struct {
+	Paging
+	SessionID string    `json:"sessionID" form:"sessionID"`                               // 会话ID
+	Action    string    `json:"action" form:"action"`                                     // 动作，如 created detect_stopped removed
+	Actor     string    `json:"actor" form:"actor"`                                       // 调用方，如 api-key:ops@t1
+	From      time.Time `json:"from" form:"from" time_format:"2006-01-02T15:04:05Z07:00"` // 起始时间（含）RFC3339
+	To        time.Time `json:"to" form:"to" time_format:"2006-01-02T15:04:05Z07:00"`     // 结束时间（不含）RFC3339
+}
+
+func (r AuditQueryReq) AuditFilter() AuditFilter {
+	return AuditFilter{SessionID: r.SessionID, Action: r.Action, Actor: r.Actor, From: r.From, To: r.To}
+}
+
+// 识别事件查询Req
+type EventQueryReq struct {
+	Paging
+	SessionID     string    `json:"sessionID" form:"sessionID"`                                // 会话ID
+	Label         string    `json:"label" form:"label"`                                        // 识别类别，如 person
+	MinConfidence float64   `json:"minConfidence" form:"minConfidence" validate:"gte=0,lte=1"` // 最低置信度
+	From          time.Time `json:"from" form:"from" time_format:"2006-01-02T15:04:05Z07:00"`  // 起始时间（含）RFC3339
+	To            time.Time `json:"to" form:"to" time_format:"2006-01-02T15:04:05Z07:00"`      // 结束时间（不含）RFC3339
+}
+
+func (r EventQueryReq) EventFilter() EventFilter {
+	return EventFilter{SessionID: r.SessionID, Label: r.Label, MinConfidence: r.MinConfidence, From: r.From, To: r.To}
+}
+
+// 识别统计查询Req
+type DetectStatsReq struct {
+	From     time.Time `json:"from" form:"from" time_format:"2006-01-02T15:04:05Z07:00"`        // 起始时间（含）RFC3339，默认 to 之前 24 小时
+	To       time.Time `json:"to" form:"to" time_format:"2006-01-02T15:04:05Z07:00"`            // 结束时间（不含）RFC3339，默认当前时间
+	Bucket   string    `json:"bucket" form:"bucket" validate:"omitempty,oneof=minute hour day"` // 时间桶粒度，默认 hour
+	Timezone string    `json:"tz" form:"tz"`                                                    // 小时与天对齐使用的 IANA 时区，默认服务器本地时区
+}
+
+// Normalize 填充默认值并解析时区
+func (r *DetectStatsReq) Normalize(now time.Time) (*time.Location, error) {
+	if r.Bucket == "" {
+		r.Bucket = StatsBucketHour
+	}
+	if r.To.IsZero() {
+		r.To = now
+	}
+	if r.From.IsZero() {
+		r.From = r.To.Add(-24 * time.Hour)
+	}
+	if !r.From.Before(r.To) {
+		return nil, errors.New("from must be before to")
+	}
+	loc := time.Local
+	if r.Timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(r.Timezone); err != nil {
+			return nil, fmt.Errorf("tz: %w", err)
+		}
+	}
+	return loc, nil
+}
+
+// 识别统计Ack
+type DetectStatsResp struct {
+	SessionID string        `json:"sessionID"`
+	Bucket    string        `json:"bucket"`
+	From      time.Time     `json:"from"`
+	To        time.Time     `json:"to"`
+	Buckets   []DetectStats `json:"buckets"` // 有数据的时间桶，按时间升序
+	Total     DetectStats   `json:"total"`   // 区间合计
+}
+
+// 日志等级Req/Ack
+type LogLevel struct {
+	Level string `json:"level" validate:"required,oneof=debug info warn error"` // 全局日志等级 debug info warn error
+}
@@ -2,31 +2,51 @@ package engine
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.uber.org/zap"
 	"go_client/config"
 	"go_client/pb"
+	"go_client/pkg/auth"
 	"go_client/pkg/logger"
+	"go_client/pkg/tlsutil"
 	"golang.org/x/net/http2"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"net"
 	"net/http"
 	"os"
+	"sync"
+	"time"
 )
 
+const defaultShutdownTimeout = 30 * time.Second // 未配置 shutdown-timeout 时的优雅关闭超时
+
 // DetectionEngine 识别Engine
 type DetectionEngine struct {
 	ctx    context.Context
 	cancel context.CancelFunc
 
-	cfg     *config.Config
-	manager *SessionManager
-	router  *gin.Engine
-	logger  *zap.Logger
-	srv     *http.Server
-	peerSrv *grpc.Server
+	cfg      *config.Config
+	manager  *SessionManager
+	router   *gin.Engine
+	logger   *zap.Logger
+	srv      *http.Server
+	peerSrv  *grpc.Server
+	tls      *tlsutil.Reloader // 未开启 TLS 时为 nil
+	logLevel zap.AtomicLevel
+	reloadMu sync.Mutex // 串行化配置重新加载
+
+	shutdownTracing func(context.Context) error // 刷新并关闭链路追踪导出
+
+	healthChecker *HealthChecker
+	healthSrv     *health.Server // gRPC Health 服务
 
 	httpService DetectHTTPService
 	grpcService pb.DetectServiceServer
@@ -45,16 +65,31 @@ func NewDetectEngine(args []string) (engine *DetectionEngine, err error) {
 	if err != nil {
 		return nil, err
 	}
+	// 启动任何组件前校验配置，一次性报告全部问题
+	if err := _config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config %s:\n%w", _config.Path(), err)
+	}
 
 	// new zap logger
-	_logger := logger.NewLogWithSplitting(logger.LogSplitting{
+	_logger, _logLevel := logger.NewLogWithSplitting(logger.LogSplitting{
 		FileName:   _config.Logger.LogPath,
 		MaxSize:    _config.Logger.SplitMaxSize,
 		MaxAge:     _config.Logger.MaxAge,
 		MaxBackups: _config.Logger.MaxBackups,
 		LocalTime:  _config.Logger.LocalTime,
 		Compress:   _config.Logger.Compress,
-	}, _config.Logger.LogLevel)
+	}, _config.Logger.LogLevel,
+		logger.WithJSON(_config.Logger.Format == config.LogFormatJSON),
+		logger.WithStdout(_config.Logger.Stdout))
+
+	// init tracing
+	_shutdownTracing, err := setupTracing(ctx, _config.Tracing)
+	if err != nil {
+		return nil, fmt.Errorf("init tracing: %w", err)
+	}
+	if _config.Tracing.Enable {
+		_logger.Info("🔭 tracing enabled", zap.String("exporter", _config.Tracing.Exporter), zap.String("endpoint", _config.Tracing.Endpoint), zap.Float64("sample_ratio", _config.Tracing.SampleRatio))
+	}
 
 	// new session manager
 	_ctx, _cancel := context.WithCancel(ctx)
@@ -68,34 +103,117 @@ func NewDetectEngine(args []string) (engine *DetectionEngine, err error) {
 		_config.Engine.PushUrlPublicPre,
 	)
 
+	// 会话生命周期审计
+	if path := _config.Engine.AuditLog; path != "" {
+		rotation := AuditRotation{
+			MaxSize:    _config.Engine.AuditMaxSize,
+			MaxAge:     _config.Engine.AuditMaxAge,
+			MaxBackups: _config.Engine.AuditMaxBackups,
+		}
+		if _manager.auditLog, err = OpenAuditLog(path, rotation, _logger); err != nil {
+			return nil, err
+		}
+	}
+
+	// 识别统计
+	if path := _config.Engine.StatsDB; path != "" {
+		retention := time.Duration(_config.Engine.StatsRetention) * 24 * time.Hour
+		if _manager.detectStats, err = OpenDetectStatsStore(path, retention, _logger); err != nil {
+			return nil, err
+		}
+		_manager.OnDetection(_manager.detectStats.Record)
+	}
+
+	// 识别事件历史
+	if path := _config.Engine.EventDB; path != "" {
+		retention := time.Duration(_config.Engine.EventRetention) * 24 * time.Hour
+		if _manager.eventStore, err = OpenEventStore(path, retention, _logger); err != nil {
+			return nil, err
+		}
+		_manager.OnDetection(_manager.eventStore.Record)
+	}
+
+	// 探测 FFmpeg 可用编码器
+	_manager.encoders = checkFFmpegEncoders(_logger, _config)
+
+	// 识别事件推送
+	if url := _config.Engine.EventWebhook; url != "" {
+		_manager.eventWebhook = NewEventWebhook(url, _logger)
+		_manager.OnDetection(_manager.eventWebhook.Record)
+	}
+
 	// ---- init gin engine ----
 	router := gin.New()
 	router.MaxMultipartMemory = 32 << 20 // 16 MB
-	router.UseH2C = _config.Server.UseH2C
+	router.UseH2C = _config.Server.UseH2C && !_config.Server.TLS.Enable
 	router.Use(
 		gin.CustomRecovery(RecoveryMiddleware(_logger)),
 		LoggerMiddleware(_logger),
 	)
+	grpcOptions := make([]grpc.ServerOption, 0)
+	if _config.Tracing.Enable {
+		router.Use(otelgin.Middleware(_config.Tracing.Service()))
+		grpcOptions = append(grpcOptions, grpc.StatsHandler(otelgrpc.NewServerHandler()))
+	}
+
+	// ---- init tls ----
+	httpMiddlewares := []gin.HandlerFunc{AuditMiddleware()}
+	var tlsReloader *tlsutil.Reloader
+	if tlsCfg := _config.Server.TLS; tlsCfg.Enable {
+		tlsReloader, err = tlsutil.NewReloader(tlsCfg.CertFile, tlsCfg.KeyFile, tlsCfg.ClientCAFile, _logger)
+		if err != nil {
+			return nil, err
+		}
+		grpcClientAuth := tls.NoClientCert
+		if tlsReloader.HasClientCA() {
+			grpcClientAuth = tls.RequireAndVerifyClientCert
+		}
+		grpcOptions = append(grpcOptions, grpc.Creds(credentials.NewTLS(tlsReloader.ServerConfig(grpcClientAuth, []string{"h2"}))))
+		_logger.Info("🔐 tls enabled", zap.Bool("mtls", tlsReloader.HasClientCA()))
+	}
+
+	// ---- init auth ----
+	if _config.Auth.Enable {
+		authenticator := auth.NewAuthenticator(_config.Auth.APIKeys, _config.Auth.JWT)
+		httpMiddlewares = append(httpMiddlewares, AuthMiddleware(authenticator, _logger))
+		grpcOptions = append(grpcOptions,
+			grpc.ChainUnaryInterceptor(AuthUnaryInterceptor(authenticator)),
+			grpc.ChainStreamInterceptor(AuthStreamInterceptor(authenticator)),
+		)
+		_logger.Info("🔐 api auth enabled", zap.Int("api_keys", len(_config.Auth.APIKeys)), zap.Bool("jwt", _config.Auth.JWT.Secret != ""))
+	}
+
+	_healthChecker := NewHealthChecker(_manager)
+	RegisterHealthHTTPService(router, NewHealthHTTPServiceV1(_healthChecker, _logger))
 
 	_httpService := NewDetectHTTPServiceV1(_config, _manager, _logger)
-	RegisterDetectHTTPService(router, _httpService)
+	RegisterDetectHTTPService(router, _httpService, httpMiddlewares...)
+	RegisterAdminHTTPService(router, NewAdminHTTPServiceV1(_logLevel, _logger), append(httpMiddlewares, AdminOnlyMiddleware())...)
 
 	// new engine
 	engine = &DetectionEngine{
-		ctx:         ctx,
-		cancel:      cancelFunc,
-		cfg:         _config,
-		manager:     _manager,
-		router:      router,
-		logger:      _logger,
-		peerSrv:     grpc.NewServer(),
-		httpService: _httpService,
+		ctx:      ctx,
+		cancel:   cancelFunc,
+		cfg:      _config,
+		manager:  _manager,
+		router:   router,
+		logger:   _logger,
+		peerSrv:  grpc.NewServer(grpcOptions...),
+		tls:      tlsReloader,
+		logLevel: _logLevel,
+
+		shutdownTracing: _shutdownTracing,
+		healthChecker:   _healthChecker,
+		healthSrv:       health.NewServer(),
+		httpService:     _httpService,
 	}
 
 	_grpcService := &DetectGRPCServiceV1{
+		cfg:     _config,
 		manager: _manager,
 	}
 	pb.RegisterDetectServiceServer(engine.peerSrv, _grpcService)
+	healthpb.RegisterHealthServer(engine.peerSrv, engine.healthSrv)
 	engine.grpcService = _grpcService
 
 	// ---- init http server ----
@@ -103,40 +221,70 @@ func NewDetectEngine(args []string) (engine *DetectionEngine, err error) {
 		Addr:    engine.cfg.Server.ListenHttpAddr,
 		Handler: engine.router,
 	}
+	if tlsReloader != nil {
+		httpClientAuth := tls.NoClientCert
+		switch {
+		case tlsReloader.HasClientCA() && _config.Server.TLS.HTTPClientAuth:
+			httpClientAuth = tls.RequireAndVerifyClientCert
+		case tlsReloader.HasClientCA():
+			httpClientAuth = tls.VerifyClientCertIfGiven
+		}
+		engine.srv.TLSConfig = tlsReloader.ServerConfig(httpClientAuth, []string{"h2", "http/1.1"})
+	}
 
 	return engine, http2.ConfigureServer(engine.srv, nil)
 }
 
 func (e *DetectionEngine) Run(endCh chan os.Signal) {
+	e.manager.SetDeclaredSessions(e.cfg.Sessions)
 	e.manager.Run()
+	e.healthChecker.WatchGRPCHealth(e.ctx, e.healthSrv, e.logger)
+	if err := e.watchConfig(); err != nil {
+		e.logger.Warn("config watch failed, declared sessions reload disabled", zap.Error(err))
+	}
+
+	if e.tls != nil {
+		if err := e.tls.Watch(e.ctx); err != nil {
+			e.logger.Warn("tls certificate watch failed, hot reload disabled", zap.Error(err))
+		}
+	}
+
+	// 服务退出时通知主程序关闭，非阻塞发送避免关闭过程中 goroutine 泄漏
+	notifyEnd := func() {
+		select {
+		case endCh <- os.Interrupt:
+		default:
+		}
+	}
 
 	go func() {
-		defer func() {
-			endCh <- os.Interrupt
-		}()
+		defer notifyEnd()
 
-		err := e.srv.ListenAndServe()
-		if errors.Is(err, http.ErrServerClosed) {
-			e.logger.Warn(fmt.Sprintf("http server error: %v", err))
+		var err error
+		if e.tls != nil {
+			err = e.srv.ListenAndServeTLS("", "")
+		} else {
+			err = e.srv.ListenAndServe()
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			e.logger.Warn("http server error", zap.Error(err))
 			return
 		}
 		return
 	}()
 
 	go func() {
-		defer func() {
-			endCh <- os.Interrupt
-		}()
+		defer notifyEnd()
 
 		listen, err := net.Listen("tcp", e.cfg.Server.GrpcPeerAddr)
 		if err != nil {
-			e.logger.Warn(fmt.Sprintf("grpc server listen error: %v", err))
+			e.logger.Warn("grpc server listen error", zap.Error(err))
 			return
 		}
 
 		err = e.peerSrv.Serve(listen)
 		if err != nil {
-			e.logger.Warn(fmt.Sprintf("grpc server error: %v", err))
+			e.logger.Warn("grpc server error", zap.Error(err))
 			return
 		}
 	}()
@@ -144,14 +292,87 @@ func (e *DetectionEngine) Run(endCh chan os.Signal) {
 	return
 }
 
-func (e *DetectionEngine) Close() {
-	defer func() {
-		// DON'T CHECK RECOVER RETURN VALUE
-		_ = recover()
+// Close 按顺序优雅关闭：停止接收 API 请求 -> 关闭各会话推流 stdin 使 FFmpeg 完成剩余写入 -> 等待进程退出
+// 超过 shutdown-timeout 后强制结束
+func (e *DetectionEngine) Close() error {
+	timeout := time.Duration(e.cfg.Server.ShutdownTimeout) * time.Second
+	if timeout <= 0 {
+		timeout = defaultShutdownTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	e.logger.Info("detect engine shutdown", zap.Duration("timeout", timeout))
+
+	// 通知编排系统不再转发流量
+	e.healthSrv.Shutdown()
+
+	// 停止接收 API 请求，等待处理中的请求完成
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		if err := e.srv.Shutdown(ctx); err != nil {
+			errs[0] = fmt.Errorf("http server shutdown: %w", err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		if err := gracefulStopGRPC(ctx, e.peerSrv); err != nil {
+			errs[1] = fmt.Errorf("grpc server shutdown: %w", err)
+		}
 	}()
+	wg.Wait()
 
-	e.peerSrv.Stop()
-	_ = e.srv.Shutdown(e.ctx)
+	// 停止所有会话
+	if err := e.manager.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("session manager shutdown: %w", err))
+	}
+
+	if err := e.manager.auditLog.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("audit log close: %w", err))
+	}
+	if err := e.manager.detectStats.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("detect stats close: %w", err))
+	}
+	if err := e.manager.eventStore.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("event store close: %w", err))
+	}
+	if err := e.manager.eventWebhook.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("event webhook close: %w", err))
+	}
+
+	// 刷新剩余 span
+	if err := e.shutdownTracing(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("tracing shutdown: %w", err))
+	}
 
-	e.manager.Close()
+	e.cancel()
+	err := errors.Join(errs...)
+	if err != nil {
+		e.logger.Warn("detect engine shutdown with error", zap.Error(err))
+	} else {
+		e.logger.Info("detect engine shutdown completed")
+	}
+	_ = e.logger.Sync()
+	return err
+}
+
+// gracefulStopGRPC 等待处理中的 RPC 完成，ctx 结束后强制停止
+func gracefulStopGRPC(ctx context.Context, srv *grpc.Server) error {
+	done := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		srv.Stop()
+		<-done
+		return ctx.Err()
+	}
 }
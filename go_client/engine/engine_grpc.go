@@ -2,8 +2,15 @@ package engine
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"go_client/config"
 	"go_client/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
+	"time"
 )
 
 type DetectGRPCServiceV1 struct {
@@ -12,77 +19,597 @@ type DetectGRPCServiceV1 struct {
 	pb.UnimplementedDetectServiceServer
 }
 
-func (d DetectGRPCServiceV1) CreateSession(_ context.Context, req *pb.CreateSessionReq) (*pb.SessionDesc, error) {
-	desc, err := d.manager.CreateSession(
-		req.Id,
-		req.RtspURL,
-		d.cfg.Engine.DetectAIURL,
-		SetSessionVideoStreamConfig(
-			int(req.Width),
-			int(req.Height),
-			int(req.Framerate),
-		))
+func (d DetectGRPCServiceV1) CreateSession(ctx context.Context, req *pb.CreateSessionReq) (*pb.SessionDesc, error) {
+	createReq, err := fromPBCreateSessionReq(req)
 	if err != nil {
-		return nil, err
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := Validate(createReq); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	encoding, err := d.manager.ResolveEncodingProfile(createReq.EncodingProfile, createReq.Encoding)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	desc, err := d.manager.CreateSession(ctx, createReq.ID, createReq.RtspURL, d.manager.Config().Engine.DetectAIURL, createReq.Options(encoding)...)
+	if err != nil {
+		return nil, toGRPCError(err)
 	}
 
-	return &pb.SessionDesc{
-		Id:            desc.ID,
-		StreamKey:     desc.StreamKey,
-		PushUrlPublic: desc.PushUrlPublic,
-		DetectStatus:  desc.DetectStatus,
-	}, nil
+	return toPBSessionDesc(desc), nil
 }
 
-func (d DetectGRPCServiceV1) GetAllSessionDesc(_ context.Context, _ *pb.Empty) (*pb.AllSessionDescResp, error) {
+func (d DetectGRPCServiceV1) GetAllSessionDesc(ctx context.Context, _ *pb.Empty) (*pb.AllSessionDescResp, error) {
 
-	descList := d.manager.GetSessionDescList()
+	descList := d.manager.GetSessionDescList(ctx)
 
 	res := make([]*pb.SessionDesc, len(descList))
 	for i := range descList {
-		res[i] = &pb.SessionDesc{
-			Id:            descList[i].ID,
-			StreamKey:     descList[i].StreamKey,
-			PushUrlPublic: descList[i].PushUrlPublic,
-			DetectStatus:  descList[i].DetectStatus,
-		}
+		res[i] = toPBSessionDesc(descList[i])
 	}
 	return &pb.AllSessionDescResp{
 		Sessions: res,
 	}, nil
 }
 
-func (d DetectGRPCServiceV1) GetSessionDescByID(_ context.Context, req *pb.SessionIDReq) (*pb.GetSessionDescByIDResp, error) {
-	desc, ok := d.manager.GetSessionDescByID(req.SessionID)
+func (d DetectGRPCServiceV1) ListSessions(ctx context.Context, req *pb.ListSessionReq) (*pb.ListSessionResp, error) {
+	listReq := ListSessionReq{
+		Paging:       Paging{Offset: uint(req.Offset), Limit: uint(req.Limit)},
+		Status:       req.Status,
+		Site:         req.Site,
+		DetectStatus: req.DetectStatus,
+		Labels:       req.Labels,
+		Query:        req.Q,
+		Sort:         req.Sort,
+	}
+	if err := Validate(listReq); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	descList, total := d.manager.ListSessionDesc(ctx, listReq.SessionFilter(), listReq.Sort, int(listReq.Offset), int(listReq.Limit))
+	res := make([]*pb.SessionDesc, len(descList))
+	for i := range descList {
+		res[i] = toPBSessionDesc(descList[i])
+	}
+	return &pb.ListSessionResp{
+		Total:    int64(total),
+		Sessions: res,
+	}, nil
+}
+
+func (d DetectGRPCServiceV1) GetSessionDescByID(ctx context.Context, req *pb.SessionIDReq) (*pb.GetSessionDescByIDResp, error) {
+	desc, ok := d.manager.GetSessionDescByID(ctx, req.SessionID)
 
 	return &pb.GetSessionDescByIDResp{
-		Exists: ok,
-		Session: &pb.SessionDesc{
-			Id:            desc.ID,
-			StreamKey:     desc.StreamKey,
-			PushUrlPublic: desc.PushUrlPublic,
-			DetectStatus:  desc.DetectStatus,
-		},
+		Exists:  ok,
+		Session: toPBSessionDesc(desc),
 	}, nil
 }
 
-func (d DetectGRPCServiceV1) StopDetect(_ context.Context, req *pb.SessionIDReq) (*pb.GenericResp, error) {
+func (d DetectGRPCServiceV1) UpdateSession(ctx context.Context, req *pb.UpdateSessionReq) (*pb.SessionDesc, error) {
+	updateReq := UpdateSessionReq{
+		RtspURL:      req.RtspURL,
+		DetectAIURL:  req.DetectAIURL,
+		DetectStatus: req.DetectStatus,
+		Debug:        req.Debug,
+
+		EncodingProfile: req.EncodingProfile,
+		Encoding:        fromPBEncodingProfile(req.Encoding),
+
+		Schedule: fromPBDetectSchedule(req.Schedule),
+		Counting: fromPBLineCounting(req.Counting),
+	}
+	if req.Width != nil {
+		width := int(*req.Width)
+		updateReq.Width = &width
+	}
+	if req.Height != nil {
+		height := int(*req.Height)
+		updateReq.Height = &height
+	}
+	if req.Framerate != nil {
+		framerate := int(*req.Framerate)
+		updateReq.Framerate = &framerate
+	}
+	if err := Validate(updateReq); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	desc, err := d.manager.UpdateSession(ctx, req.SessionID, updateReq.SessionUpdate())
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+
+	return toPBSessionDesc(desc), nil
+}
+
+func (d DetectGRPCServiceV1) StopDetect(ctx context.Context, req *pb.SessionIDReq) (*pb.GenericResp, error) {
 
-	err := d.manager.StopSessionDetect(req.SessionID)
+	if err := d.manager.StopSessionDetect(ctx, req.SessionID); err != nil {
+		return nil, toGRPCError(err)
+	}
 
-	return &pb.GenericResp{Ok: true}, err
+	return &pb.GenericResp{Ok: true}, nil
 }
 
-func (d DetectGRPCServiceV1) ContinueDetect(_ context.Context, req *pb.SessionIDReq) (*pb.GenericResp, error) {
+func (d DetectGRPCServiceV1) ContinueDetect(ctx context.Context, req *pb.SessionIDReq) (*pb.GenericResp, error) {
 
-	err := d.manager.StartSessionDetect(req.SessionID)
+	if err := d.manager.StartSessionDetect(ctx, req.SessionID); err != nil {
+		return nil, toGRPCError(err)
+	}
 
-	return &pb.GenericResp{Ok: true}, err
+	return &pb.GenericResp{Ok: true}, nil
 }
 
-func (d DetectGRPCServiceV1) RemoveSession(_ context.Context, req *pb.SessionIDReq) (*pb.GenericResp, error) {
+func (d DetectGRPCServiceV1) RemoveSession(ctx context.Context, req *pb.SessionIDReq) (*pb.GenericResp, error) {
 
-	d.manager.RemoveSession(req.SessionID)
+	if err := d.manager.RemoveSession(ctx, req.SessionID); err != nil {
+		return nil, toGRPCError(err)
+	}
 
 	return &pb.GenericResp{Ok: true}, nil
 }
+
+func toPBSessionDesc(desc SessionDesc) *pb.SessionDesc {
+	res := &pb.SessionDesc{
+		Id:             desc.ID,
+		StreamKey:      desc.StreamKey,
+		PushUrlPublic:  desc.PushUrlPublic,
+		DetectStatus:   desc.DetectStatus,
+		Debug:          desc.Debug,
+		Tenant:         desc.Tenant,
+		Name:           desc.Name,
+		Site:           desc.Site,
+		Labels:         desc.Labels,
+		Metadata:       string(desc.Metadata),
+		State:          desc.State,
+		Width:          int32(desc.Width),
+		Height:         int32(desc.Height),
+		Framerate:      int32(desc.Framerate),
+		Encoding:       toPBEncodingProfile(desc.Encoding),
+		ReconnectCount: desc.ReconnectCount,
+		StallRestarts:  desc.StallRestarts,
+		UptimeSeconds:  desc.UptimeSeconds,
+		LastError:      toPBSessionError(desc.LastError),
+		Errors:         make([]*pb.SessionError, len(desc.Errors)),
+		Stats: &pb.SessionStats{
+			InputFps:       desc.Stats.InputFPS,
+			OutputFps:      desc.Stats.OutputFPS,
+			DetectFps:      desc.Stats.DetectFPS,
+			AvgAILatencyMs: desc.Stats.AvgAILatencyMs,
+
+			LastFrameReadAt:   unixMilli(desc.Stats.LastFrameReadAt),
+			LastFramePushedAt: unixMilli(desc.Stats.LastFramePushedAt),
+		},
+	}
+	for i := range desc.Errors {
+		res.Errors[i] = toPBSessionError(&desc.Errors[i])
+	}
+	res.Schedule = toPBDetectSchedule(desc.Schedule)
+	res.Counting = toPBLineCounting(desc.Counting)
+	if desc.ScheduleOverride != nil {
+		res.ScheduleOverride = &pb.ScheduleOverride{Detect: desc.ScheduleOverride.Detect, Until: unixMilli(desc.ScheduleOverride.Until)}
+	}
+	if desc.NextTransition != nil {
+		res.NextTransition = &pb.ScheduleTransition{Time: unixMilli(desc.NextTransition.Time), Detect: desc.NextTransition.Detect}
+	}
+	return res
+}
+
+func toPBDetectSchedule(schedule *DetectSchedule) *pb.DetectSchedule {
+	if schedule == nil {
+		return nil
+	}
+	res := &pb.DetectSchedule{
+		Timezone: schedule.Timezone,
+		Start:    schedule.Start,
+		Stop:     schedule.Stop,
+		Windows:  make([]*pb.ScheduleWindow, len(schedule.Windows)),
+	}
+	for i, window := range schedule.Windows {
+		res.Windows[i] = &pb.ScheduleWindow{Days: window.Days, Start: window.Start, End: window.End}
+	}
+	return res
+}
+
+func toPBLineCounting(counting *LineCounting) *pb.LineCounting {
+	if counting == nil {
+		return nil
+	}
+	res := &pb.LineCounting{Reset_: counting.Reset, Timezone: counting.Timezone, Lines: make([]*pb.CountingLine, len(counting.Lines))}
+	for i, line := range counting.Lines {
+		res.Lines[i] = &pb.CountingLine{
+			Name:   line.Name,
+			X1:     int32(line.X1),
+			Y1:     int32(line.Y1),
+			X2:     int32(line.X2),
+			Y2:     int32(line.Y2),
+			Labels: line.Labels,
+		}
+	}
+	return res
+}
+
+func fromPBLineCounting(counting *pb.LineCounting) *LineCounting {
+	if counting == nil {
+		return nil
+	}
+	res := &LineCounting{Reset: counting.Reset_, Timezone: counting.Timezone, Lines: make([]CountingLine, len(counting.Lines))}
+	for i, line := range counting.Lines {
+		res.Lines[i] = CountingLine{
+			Name:   line.Name,
+			X1:     int(line.X1),
+			Y1:     int(line.Y1),
+			X2:     int(line.X2),
+			Y2:     int(line.Y2),
+			Labels: line.Labels,
+		}
+	}
+	return res
+}
+
+func fromPBDetectSchedule(schedule *pb.DetectSchedule) *DetectSchedule {
+	if schedule == nil {
+		return nil
+	}
+	res := &DetectSchedule{
+		Timezone: schedule.Timezone,
+		Start:    schedule.Start,
+		Stop:     schedule.Stop,
+		Windows:  make([]ScheduleWindow, len(schedule.Windows)),
+	}
+	for i, window := range schedule.Windows {
+		res.Windows[i] = ScheduleWindow{Days: window.Days, Start: window.Start, End: window.End}
+	}
+	return res
+}
+
+func toPBEncodingProfile(profile config.EncodingProfile) *pb.EncodingProfile {
+	return &pb.EncodingProfile{
+		Codec:        profile.Codec,
+		Preset:       profile.Preset,
+		Tune:         profile.Tune,
+		Bitrate:      int32(profile.Bitrate),
+		Crf:          int32(profile.CRF),
+		Gop:          int32(profile.GOP),
+		OutputWidth:  int32(profile.OutputWidth),
+		OutputHeight: int32(profile.OutputHeight),
+		Format:       profile.Format,
+		Clear:        profile.Clear,
+	}
+}
+
+func fromPBEncodingProfile(profile *pb.EncodingProfile) *config.EncodingProfile {
+	if profile == nil {
+		return nil
+	}
+	return &config.EncodingProfile{
+		Codec:        profile.Codec,
+		Preset:       profile.Preset,
+		Tune:         profile.Tune,
+		Bitrate:      int(profile.Bitrate),
+		CRF:          int(profile.Crf),
+		GOP:          int(profile.Gop),
+		OutputWidth:  int(profile.OutputWidth),
+		OutputHeight: int(profile.OutputHeight),
+		Format:       profile.Format,
+		Clear:        profile.Clear,
+	}
+}
+
+func toPBSessionError(sessionErr *SessionError) *pb.SessionError {
+	if sessionErr == nil {
+		return nil
+	}
+	return &pb.SessionError{
+		Time:     sessionErr.Time.UnixMilli(),
+		Stage:    sessionErr.Stage,
+		Message:  sessionErr.Message,
+		Code:     sessionErr.Code,
+		Count:    int32(sessionErr.Count),
+		LastSeen: sessionErr.LastSeen.UnixMilli(),
+	}
+}
+
+// unixMilli 时间转换为 Unix 毫秒，零值返回 0
+func unixMilli(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
+
+// toGRPCError 将会话管理错误映射为 gRPC 状态码
+func toGRPCError(err error) error {
+	switch {
+	case errors.Is(err, ErrSessionNotExists):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrSessionExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, ErrInvalidEncoding), errors.Is(err, ErrInvalidSchedule), errors.Is(err, ErrInvalidLineCounting):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, ErrQuotaExceeded):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, ErrSessionNotRunning), errors.Is(err, ErrSessionDeclared):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return err
+	}
+}
+
+func fromPBCreateSessionReq(req *pb.CreateSessionReq) (CreateSessionReq, error) {
+	createReq := CreateSessionReq{
+		ID:              req.Id,
+		RtspURL:         req.RtspURL,
+		Width:           int(req.Width),
+		Height:          int(req.Height),
+		RetryTimes:      int(req.RetryTimes),
+		Framerate:       int(req.Framerate),
+		EncodingProfile: req.EncodingProfile,
+		Encoding:        fromPBEncodingProfile(req.Encoding),
+		Name:            req.Name,
+		Site:            req.Site,
+		Labels:          req.Labels,
+		Schedule:        fromPBDetectSchedule(req.Schedule),
+		Counting:        fromPBLineCounting(req.Counting),
+	}
+	if req.Metadata != "" {
+		if !json.Valid([]byte(req.Metadata)) {
+			return createReq, errors.New("metadata is not valid json")
+		}
+		createReq.Metadata = json.RawMessage(req.Metadata)
+	}
+	return createReq, nil
+}
+
+func (d DetectGRPCServiceV1) BulkCreateSessions(ctx context.Context, req *pb.BulkCreateSessionReq) (*pb.BulkResp, error) {
+	var reqs []CreateSessionReq
+	if req.Csv != "" {
+		var err error
+		if reqs, err = ParseSessionInventoryCSV(strings.NewReader(req.Csv)); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	for _, item := range req.Sessions {
+		createReq, err := fromPBCreateSessionReq(item)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("session %s: %v", item.Id, err))
+		}
+		reqs = append(reqs, createReq)
+	}
+	if err := Validate(BulkCreateSessionReq{Sessions: reqs}); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return toPBBulkResp(d.manager.BulkCreateSessions(ctx, reqs)), nil
+}
+
+func (d DetectGRPCServiceV1) BulkStartDetect(ctx context.Context, req *pb.BulkSelectorReq) (*pb.BulkResp, error) {
+	return d.bulkApply(ctx, req, func(ctx context.Context, ids []string) BulkResult {
+		return d.manager.BulkSetDetectStatus(ctx, ids, true)
+	})
+}
+
+func (d DetectGRPCServiceV1) BulkStopDetect(ctx context.Context, req *pb.BulkSelectorReq) (*pb.BulkResp, error) {
+	return d.bulkApply(ctx, req, func(ctx context.Context, ids []string) BulkResult {
+		return d.manager.BulkSetDetectStatus(ctx, ids, false)
+	})
+}
+
+func (d DetectGRPCServiceV1) BulkRemoveSessions(ctx context.Context, req *pb.BulkSelectorReq) (*pb.BulkResp, error) {
+	return d.bulkApply(ctx, req, d.manager.BulkRemoveSessions)
+}
+
+func (d DetectGRPCServiceV1) ListAuditEvents(ctx context.Context, req *pb.AuditQueryReq) (*pb.AuditEventsResp, error) {
+	query := AuditQueryReq{
+		Paging:    Paging{Offset: uint(req.Offset), Limit: uint(req.Limit)},
+		SessionID: req.SessionID,
+		Action:    req.Action,
+		Actor:     req.Actor,
+	}
+	if req.From > 0 {
+		query.From = time.UnixMilli(req.From)
+	}
+	if req.To > 0 {
+		query.To = time.UnixMilli(req.To)
+	}
+	if err := Validate(query); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	events, total, err := d.manager.AuditEvents(ctx, query.AuditFilter(), int(query.Offset), int(query.Limit))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	res := make([]*pb.AuditEvent, len(events))
+	for i, event := range events {
+		res[i] = &pb.AuditEvent{
+			Time:      event.Time.UnixMilli(),
+			SessionID: event.SessionID,
+			Tenant:    event.Tenant,
+			Action:    event.Action,
+			Actor:     event.Actor,
+			Peer:      event.Peer,
+			Reason:    event.Reason,
+		}
+	}
+	return &pb.AuditEventsResp{Total: int64(total), Events: res}, nil
+}
+
+func (d DetectGRPCServiceV1) ListDetectionEvents(ctx context.Context, req *pb.EventQueryReq) (*pb.EventsResp, error) {
+	query := EventQueryReq{
+		Paging:        Paging{Offset: uint(req.Offset), Limit: uint(req.Limit)},
+		SessionID:     req.SessionID,
+		Label:         req.Label,
+		MinConfidence: req.MinConfidence,
+	}
+	if req.From > 0 {
+		query.From = time.UnixMilli(req.From)
+	}
+	if req.To > 0 {
+		query.To = time.UnixMilli(req.To)
+	}
+	if err := Validate(query); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	events, total, err := d.manager.DetectionEvents(ctx, query.EventFilter(), int(query.Offset), int(query.Limit))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	res := make([]*pb.DetectionEvent, len(events))
+	for i, event := range events {
+		res[i] = &pb.DetectionEvent{
+			Id:        event.ID,
+			Kind:      event.Kind,
+			SessionID: event.SessionID,
+			Tenant:    event.Tenant,
+			Name:      event.Name,
+			Site:      event.Site,
+			Labels:    event.Labels,
+			Metadata:  string(event.Metadata),
+			Time:      event.Time.UnixMilli(),
+			Results:   make([]*pb.DetectionResult, len(event.Results)),
+			Snapshot:  event.Snapshot,
+		}
+		for j, result := range event.Results {
+			res[i].Results[j] = &pb.DetectionResult{
+				X1:    int32(result.X1),
+				Y1:    int32(result.Y1),
+				X2:    int32(result.X2),
+				Y2:    int32(result.Y2),
+				Label: result.Label,
+				Conf:  result.Conf,
+			}
+		}
+	}
+	return &pb.EventsResp{Total: int64(total), Events: res}, nil
+}
+
+func (d DetectGRPCServiceV1) GetLineCounts(ctx context.Context, req *pb.SessionIDReq) (*pb.LineCountsResp, error) {
+	counts, err := d.manager.LineCounts(ctx, req.SessionID)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	return toPBLineCounts(counts), nil
+}
+
+func (d DetectGRPCServiceV1) ResetLineCounts(ctx context.Context, req *pb.SessionIDReq) (*pb.LineCountsResp, error) {
+	counts, err := d.manager.ResetLineCounts(ctx, req.SessionID)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	return toPBLineCounts(counts), nil
+}
+
+func toPBLineCounts(counts LineCounts) *pb.LineCountsResp {
+	res := &pb.LineCountsResp{Since: unixMilli(counts.Since), Lines: make([]*pb.LineCount, len(counts.Lines))}
+	if counts.NextReset != nil {
+		res.NextReset = unixMilli(*counts.NextReset)
+	}
+	for i, line := range counts.Lines {
+		res.Lines[i] = &pb.LineCount{Name: line.Name, In: line.In, Out: line.Out, Labels: make(map[string]*pb.CrossCount, len(line.Labels))}
+		for label, count := range line.Labels {
+			res.Lines[i].Labels[label] = &pb.CrossCount{In: count.In, Out: count.Out}
+		}
+	}
+	return res
+}
+
+func (d DetectGRPCServiceV1) GetDetectStats(ctx context.Context, req *pb.DetectStatsReq) (*pb.DetectStatsResp, error) {
+	query := DetectStatsReq{Bucket: req.Bucket, Timezone: req.Timezone}
+	if req.From > 0 {
+		query.From = time.UnixMilli(req.From)
+	}
+	if req.To > 0 {
+		query.To = time.UnixMilli(req.To)
+	}
+	if err := Validate(query); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	loc, err := query.Normalize(time.Now())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	buckets, total, err := d.manager.DetectStats(ctx, req.SessionID, query.From, query.To, query.Bucket, loc)
+	if err != nil {
+		if errors.Is(err, ErrSessionNotExists) {
+			return nil, toGRPCError(err)
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	res := &pb.DetectStatsResp{
+		Bucket:  query.Bucket,
+		From:    query.From.UnixMilli(),
+		To:      query.To.UnixMilli(),
+		Buckets: make([]*pb.DetectStats, len(buckets)),
+		Total:   toPBDetectStats(total),
+	}
+	for i := range buckets {
+		res.Buckets[i] = toPBDetectStats(buckets[i])
+	}
+	return res, nil
+}
+
+func toPBDetectStats(stats DetectStats) *pb.DetectStats {
+	res := &pb.DetectStats{
+		Start:  stats.Start.UnixMilli(),
+		Frames: stats.Frames,
+		Peak:   int32(stats.Peak),
+		Labels: make(map[string]*pb.LabelStats, len(stats.Labels)),
+	}
+	for name, label := range stats.Labels {
+		res.Labels[name] = &pb.LabelStats{Count: label.Count, Peak: int32(label.Peak), AvgConfidence: label.AvgConfidence}
+	}
+	return res
+}
+
+func (d DetectGRPCServiceV1) SetScheduleOverride(ctx context.Context, req *pb.ScheduleOverrideReq) (*pb.SessionDesc, error) {
+	var (
+		desc SessionDesc
+		err  error
+	)
+	if req.Detect == nil {
+		desc, err = d.manager.ClearScheduleOverride(ctx, req.SessionID)
+	} else {
+		var until time.Time
+		if req.Until > 0 {
+			until = time.UnixMilli(req.Until)
+		}
+		desc, err = d.manager.SetScheduleOverride(ctx, req.SessionID, *req.Detect, until)
+	}
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	return toPBSessionDesc(desc), nil
+}
+
+func (d DetectGRPCServiceV1) bulkApply(ctx context.Context, req *pb.BulkSelectorReq, apply func(ctx context.Context, ids []string) BulkResult) (*pb.BulkResp, error) {
+	selector := BulkSelectorReq{IDs: req.Ids, Labels: req.Labels}
+	if err := Validate(selector); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	ids := d.manager.SelectSessionIDs(ctx, selector.IDs, selector.Labels)
+	return toPBBulkResp(apply(ctx, ids)), nil
+}
+
+func toPBBulkResp(res BulkResult) *pb.BulkResp {
+	items := make([]*pb.BulkItemResult, len(res.Items))
+	for i, item := range res.Items {
+		items[i] = &pb.BulkItemResult{
+			Id:    item.ID,
+			Ok:    item.OK,
+			Error: item.Error,
+		}
+		if item.Session != nil {
+			items[i].Session = toPBSessionDesc(*item.Session)
+		}
+	}
+	return &pb.BulkResp{
+		Total:     int32(res.Total),
+		Succeeded: int32(res.Succeeded),
+		Failed:    int32(res.Failed),
+		Items:     items,
+	}
+}
@@ -0,0 +1,30 @@
+package engine
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"go_client/config"
+	"go_client/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestGRPCCreateSessionValidates(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	srv := DetectGRPCServiceV1{manager: NewSessionManager(ctx, cancel, zap.NewNop(), &config.Config{}, 60, "", "")}
+
+	for name, req := range map[string]*pb.CreateSessionReq{
+		"missing rtsp url": {Id: "cam-1", RetryTimes: 3},
+		"negative width":   {Id: "cam-1", RtspURL: "rtsp://10.0.0.1/live", RetryTimes: 3, Width: -1},
+		"empty label key":  {Id: "cam-1", RtspURL: "rtsp://10.0.0.1/live", RetryTimes: 3, Labels: map[string]string{"": "x"}},
+	} {
+		if _, err := srv.CreateSession(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: want InvalidArgument, got %v", name, err)
+		}
+	}
+	if _, ok := srv.manager.sessions.Load("cam-1"); ok {
+		t.Fatal("invalid session created")
+	}
+}
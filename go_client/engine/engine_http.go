@@ -1,7 +1,9 @@
 package engine
 
 import (
+	"context"
 	"encoding/base64"
+	"errors"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 	"go_client/config"
@@ -12,30 +14,72 @@ import (
 	"image/color"
 	"io"
 	"net/http"
+	"time"
 )
 
 type DetectHTTPService interface {
 	CreateSession(c *gin.Context) error      // 创建识别会话
-	GetAllSessionDesc(c *gin.Context) error  // 获取会话描述列表
+	GetAllSessionDesc(c *gin.Context) error  // 获取会话描述列表，支持过滤、排序与分页
 	GetSessionDescByID(c *gin.Context) error // 根据ID获取会话描述
+	UpdateSession(c *gin.Context) error      // 运行时更新会话配置（streamKey 不变）
+	GetFFmpegLogs(c *gin.Context) error      // 获取会话最近的 FFmpeg 日志
 	StopDetect(c *gin.Context) error         // 暂停识别（仍保持推流）
 	StartDetect(c *gin.Context) error        // 继续识别（仍保持推流）
 	RemoveSession(c *gin.Context) error      // 删除会话并停止拉流推流
+	GetSessionHistory(c *gin.Context) error  // 获取会话生命周期审计事件，会话删除后仍可查询
+	GetAuditEvents(c *gin.Context) error     // 全局审计事件查询
+	GetDetectStats(c *gin.Context) error     // 获取会话识别统计，按时间桶聚合
+	GetLineCounts(c *gin.Context) error      // 获取会话虚拟线进出计数
+	ResetLineCounts(c *gin.Context) error    // 手动清零会话虚拟线计数
+	Metrics(c *gin.Context) error            // Prometheus 文本格式的虚拟线计数
+	GetSessionEvents(c *gin.Context) error   // 获取会话识别事件历史
+	GetEvents(c *gin.Context) error          // 全局识别事件查询
+
+	SetScheduleOverride(c *gin.Context) error   // 手动覆盖识别时间计划，到期后恢复按计划执行
+	ClearScheduleOverride(c *gin.Context) error // 取消手动覆盖
+
+	BulkCreateSessions(c *gin.Context) error // 批量创建会话，支持 JSON 与 CSV 清单
+	BulkStartDetect(c *gin.Context) error    // 批量继续识别
+	BulkStopDetect(c *gin.Context) error     // 批量暂停识别
+	BulkRemoveSessions(c *gin.Context) error // 批量删除会话
 
 	DetectTest(c *gin.Context) error // 测试
 }
 
-func RegisterDetectHTTPService(eng *gin.Engine, srv DetectHTTPService) {
-	eng.POST("/test", WrapHandler(srv.DetectTest))
+// RegisterDetectHTTPService 注册识别服务路由，middlewares 作用于全部识别服务路由（如认证）
+func RegisterDetectHTTPService(eng *gin.Engine, srv DetectHTTPService, middlewares ...gin.HandlerFunc) {
+	api := eng.Group("", middlewares...)
+	api.POST("/test", WrapHandler(srv.DetectTest))
+
+	api.GET("/detect/audit", WrapHandler(srv.GetAuditEvents))
+	api.GET("/detect/events", WrapHandler(srv.GetEvents))
+	api.GET("/metrics", WrapHandler(srv.Metrics))
 
-	detect := eng.Group("/detect/session")
+	detect := api.Group("/detect/session")
 	{
 		detect.POST("", WrapHandler(srv.CreateSession))
 		detect.GET("/list", WrapHandler(srv.GetAllSessionDesc))
 
+		bulk := detect.Group("/bulk")
+		{
+			bulk.POST("", WrapHandler(srv.BulkCreateSessions))
+			bulk.PUT("/detect/start", WrapHandler(srv.BulkStartDetect))
+			bulk.PUT("/detect/stop", WrapHandler(srv.BulkStopDetect))
+			bulk.POST("/remove", WrapHandler(srv.BulkRemoveSessions))
+		}
+
 		action := detect.Group("/:sessionID")
 		{
 			action.GET("", WrapHandler(srv.GetSessionDescByID))
+			action.PATCH("", WrapHandler(srv.UpdateSession))
+			action.GET("/ffmpeg/logs", WrapHandler(srv.GetFFmpegLogs))
+			action.GET("/history", WrapHandler(srv.GetSessionHistory))
+			action.GET("/stats", WrapHandler(srv.GetDetectStats))
+			action.GET("/events", WrapHandler(srv.GetSessionEvents))
+			action.GET("/counts", WrapHandler(srv.GetLineCounts))
+			action.DELETE("/counts", WrapHandler(srv.ResetLineCounts))
+			action.PUT("/schedule/override", WrapHandler(srv.SetScheduleOverride))
+			action.DELETE("/schedule/override", WrapHandler(srv.ClearScheduleOverride))
 			action.PUT("/detect/stop", WrapHandler(srv.StopDetect))
 			action.PUT("/detect/start", WrapHandler(srv.StartDetect))
 			action.DELETE("", WrapHandler(srv.RemoveSession))
@@ -76,8 +120,8 @@ func (d DetectHTTPServiceV1) DetectTest(c *gin.Context) error {
 	}
 
 	// 2. 调用 detectObjects
-	aiURL := d.cfg.Engine.DetectAIURL
-	results, err := detectObjects(imgBytes, aiURL)
+	aiURL := d.manager.Config().Engine.DetectAIURL
+	results, err := detectObjects(c.Request.Context(), imgBytes, aiURL)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return nil
@@ -123,9 +167,21 @@ func (d DetectHTTPServiceV1) CreateSession(c *gin.Context) error {
 	if err != nil {
 		return err
 	}
-	desc, err := d.manager.CreateSession(req.ID, req.RtspURL, d.cfg.Engine.DetectAIURL,
-		SetSessionVideoStreamConfig(req.Width, req.Height, req.Framerate))
+	encoding, err := d.manager.ResolveEncodingProfile(req.EncodingProfile, req.Encoding)
 	if err != nil {
+		return status.Wrapper(http.StatusBadRequest, err)
+	}
+	desc, err := d.manager.CreateSession(c.Request.Context(), req.ID, req.RtspURL, d.manager.Config().Engine.DetectAIURL, req.Options(encoding)...)
+	if err != nil {
+		if errors.Is(err, ErrQuotaExceeded) {
+			return status.Wrapper(http.StatusTooManyRequests, err)
+		}
+		if errors.Is(err, ErrInvalidSchedule) || errors.Is(err, ErrInvalidLineCounting) {
+			return status.Wrapper(http.StatusBadRequest, err)
+		}
+		if errors.Is(err, ErrSessionExists) {
+			return status.Wrapper(http.StatusConflict, err)
+		}
 		return status.Wrapper(http.StatusInternalServerError, err)
 	}
 
@@ -136,8 +192,19 @@ func (d DetectHTTPServiceV1) CreateSession(c *gin.Context) error {
 }
 
 func (d DetectHTTPServiceV1) GetAllSessionDesc(c *gin.Context) error {
-	descList := d.manager.GetSessionDescList()
-	result.New[[]SessionDesc](http.StatusOK).Data(descList).Ok(c.Writer)
+	var req ListSessionReq
+	if err := BindQuery(&req, c.Request); err != nil {
+		return err
+	}
+
+	descList, total := d.manager.ListSessionDesc(c.Request.Context(), req.SessionFilter(), req.Sort, int(req.Offset), int(req.Limit))
+	list := make([]*SessionDesc, len(descList))
+	for i := range descList {
+		list[i] = &descList[i]
+	}
+	result.New[PagingAck[SessionDesc]](http.StatusOK).
+		Data(PagingAck[SessionDesc]{Total: int64(total), List: list}).
+		Ok(c.Writer)
 	return nil
 }
 
@@ -146,7 +213,7 @@ func (d DetectHTTPServiceV1) GetSessionDescByID(c *gin.Context) error {
 	if err := BindParams(&action, c.Params); err != nil {
 		return err
 	}
-	desc, ok := d.manager.GetSessionDescByID(action.SessionID)
+	desc, ok := d.manager.GetSessionDescByID(c.Request.Context(), action.SessionID)
 
 	result.New[GetSessionDescByIDResp](http.StatusOK).
 		Data(GetSessionDescByIDResp{ok, desc}).
@@ -154,17 +221,248 @@ func (d DetectHTTPServiceV1) GetSessionDescByID(c *gin.Context) error {
 	return nil
 }
 
-func (d DetectHTTPServiceV1) StopDetect(c *gin.Context) error {
+func (d DetectHTTPServiceV1) UpdateSession(c *gin.Context) error {
+	var action SessionAction
+	if err := BindParams(&action, c.Params); err != nil {
+		return err
+	}
+	var req UpdateSessionReq
+	if err := Bind(&req, c.Request.Body); err != nil {
+		return err
+	}
+
+	desc, err := d.manager.UpdateSession(c.Request.Context(), action.SessionID, req.SessionUpdate())
+	if err != nil {
+		if errors.Is(err, ErrSessionNotExists) {
+			return status.Wrapper(http.StatusNotFound, err)
+		}
+		if errors.Is(err, ErrInvalidEncoding) || errors.Is(err, ErrInvalidSchedule) || errors.Is(err, ErrInvalidLineCounting) {
+			return status.Wrapper(http.StatusBadRequest, err)
+		}
+		if errors.Is(err, ErrQuotaExceeded) {
+			return status.Wrapper(http.StatusTooManyRequests, err)
+		}
+		if errors.Is(err, ErrSessionNotRunning) {
+			return status.Wrapper(http.StatusConflict, err)
+		}
+		return status.Wrapper(http.StatusInternalServerError, err)
+	}
+
+	result.New[SessionDesc](http.StatusOK).
+		Data(desc).
+		Ok(c.Writer)
+	return nil
+}
+
+func (d DetectHTTPServiceV1) GetFFmpegLogs(c *gin.Context) error {
+	var action SessionAction
+	if err := BindParams(&action, c.Params); err != nil {
+		return err
+	}
+	var req GetFFmpegLogsReq
+	if err := BindQuery(&req, c.Request); err != nil {
+		return err
+	}
+
+	lines, err := d.manager.GetSessionFFmpegLogs(c.Request.Context(), action.SessionID, req.Source, req.Limit)
+	if err != nil {
+		return status.Wrapper(http.StatusNotFound, err)
+	}
+
+	result.New[[]FFmpegLogLine](http.StatusOK).Data(lines).Ok(c.Writer)
+	return nil
+}
+
+func (d DetectHTTPServiceV1) GetSessionHistory(c *gin.Context) error {
+	var action SessionAction
+	if err := BindParams(&action, c.Params); err != nil {
+		return err
+	}
+	var req AuditQueryReq
+	if err := BindQuery(&req, c.Request); err != nil {
+		return err
+	}
+	req.SessionID = action.SessionID
+	return d.writeAuditEvents(c, req)
+}
+
+func (d DetectHTTPServiceV1) GetAuditEvents(c *gin.Context) error {
+	var req AuditQueryReq
+	if err := BindQuery(&req, c.Request); err != nil {
+		return err
+	}
+	return d.writeAuditEvents(c, req)
+}
+
+func (d DetectHTTPServiceV1) writeAuditEvents(c *gin.Context, req AuditQueryReq) error {
+	events, total, err := d.manager.AuditEvents(c.Request.Context(), req.AuditFilter(), int(req.Offset), int(req.Limit))
+	if err != nil {
+		return status.Wrapper(http.StatusInternalServerError, err)
+	}
+	list := make([]*AuditEvent, len(events))
+	for i := range events {
+		list[i] = &events[i]
+	}
+	result.New[PagingAck[AuditEvent]](http.StatusOK).
+		Data(PagingAck[AuditEvent]{Total: int64(total), List: list}).
+		Ok(c.Writer)
+	return nil
+}
+
+func (d DetectHTTPServiceV1) GetSessionEvents(c *gin.Context) error {
+	var action SessionAction
+	if err := BindParams(&action, c.Params); err != nil {
+		return err
+	}
+	var req EventQueryReq
+	if err := BindQuery(&req, c.Request); err != nil {
+		return err
+	}
+	req.SessionID = action.SessionID
+	return d.writeEvents(c, req)
+}
+
+func (d DetectHTTPServiceV1) GetEvents(c *gin.Context) error {
+	var req EventQueryReq
+	if err := BindQuery(&req, c.Request); err != nil {
+		return err
+	}
+	return d.writeEvents(c, req)
+}
+
+func (d DetectHTTPServiceV1) writeEvents(c *gin.Context, req EventQueryReq) error {
+	events, total, err := d.manager.DetectionEvents(c.Request.Context(), req.EventFilter(), int(req.Offset), int(req.Limit))
+	if err != nil {
+		return status.Wrapper(http.StatusInternalServerError, err)
+	}
+	list := make([]*StoredEvent, len(events))
+	for i := range events {
+		list[i] = &events[i]
+	}
+	result.New[PagingAck[StoredEvent]](http.StatusOK).
+		Data(PagingAck[StoredEvent]{Total: int64(total), List: list}).
+		Ok(c.Writer)
+	return nil
+}
+
+func (d DetectHTTPServiceV1) GetLineCounts(c *gin.Context) error {
+	var action SessionAction
+	if err := BindParams(&action, c.Params); err != nil {
+		return err
+	}
+
+	counts, err := d.manager.LineCounts(c.Request.Context(), action.SessionID)
+	if err != nil {
+		if errors.Is(err, ErrSessionNotExists) {
+			return status.Wrapper(http.StatusNotFound, err)
+		}
+		return status.Wrapper(http.StatusBadRequest, err)
+	}
+
+	result.New[LineCounts](http.StatusOK).Data(counts).Ok(c.Writer)
+	return nil
+}
+
+func (d DetectHTTPServiceV1) ResetLineCounts(c *gin.Context) error {
+	var action SessionAction
+	if err := BindParams(&action, c.Params); err != nil {
+		return err
+	}
+
+	counts, err := d.manager.ResetLineCounts(c.Request.Context(), action.SessionID)
+	if err != nil {
+		if errors.Is(err, ErrSessionNotExists) {
+			return status.Wrapper(http.StatusNotFound, err)
+		}
+		return status.Wrapper(http.StatusBadRequest, err)
+	}
+
+	result.New[LineCounts](http.StatusOK).Data(counts).Ok(c.Writer)
+	return nil
+}
+
+func (d DetectHTTPServiceV1) Metrics(c *gin.Context) error {
+	c.Header("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	c.Status(http.StatusOK)
+	return d.manager.WriteLineCountMetrics(c.Request.Context(), c.Writer)
+}
+
+func (d DetectHTTPServiceV1) GetDetectStats(c *gin.Context) error {
 	var action SessionAction
 	if err := BindParams(&action, c.Params); err != nil {
 		return err
 	}
+	var req DetectStatsReq
+	if err := BindQuery(&req, c.Request); err != nil {
+		return err
+	}
+	loc, err := req.Normalize(time.Now())
+	if err != nil {
+		return status.Wrapper(http.StatusBadRequest, err)
+	}
 
-	err := d.manager.StopSessionDetect(action.SessionID)
+	buckets, total, err := d.manager.DetectStats(c.Request.Context(), action.SessionID, req.From, req.To, req.Bucket, loc)
 	if err != nil {
+		if errors.Is(err, ErrSessionNotExists) {
+			return status.Wrapper(http.StatusNotFound, err)
+		}
 		return status.Wrapper(http.StatusInternalServerError, err)
 	}
 
+	result.New[DetectStatsResp](http.StatusOK).
+		Data(DetectStatsResp{SessionID: action.SessionID, Bucket: req.Bucket, From: req.From, To: req.To, Buckets: buckets, Total: total}).
+		Ok(c.Writer)
+	return nil
+}
+
+func (d DetectHTTPServiceV1) SetScheduleOverride(c *gin.Context) error {
+	var action SessionAction
+	if err := BindParams(&action, c.Params); err != nil {
+		return err
+	}
+	var req ScheduleOverrideReq
+	if err := Bind(&req, c.Request.Body); err != nil {
+		return err
+	}
+
+	desc, err := d.manager.SetScheduleOverride(c.Request.Context(), action.SessionID, req.Detect, req.Until)
+	if err != nil {
+		if errors.Is(err, ErrSessionNotExists) {
+			return status.Wrapper(http.StatusNotFound, err)
+		}
+		return status.Wrapper(http.StatusBadRequest, err)
+	}
+
+	result.New[SessionDesc](http.StatusOK).Data(desc).Ok(c.Writer)
+	return nil
+}
+
+func (d DetectHTTPServiceV1) ClearScheduleOverride(c *gin.Context) error {
+	var action SessionAction
+	if err := BindParams(&action, c.Params); err != nil {
+		return err
+	}
+
+	desc, err := d.manager.ClearScheduleOverride(c.Request.Context(), action.SessionID)
+	if err != nil {
+		return status.Wrapper(http.StatusNotFound, err)
+	}
+
+	result.New[SessionDesc](http.StatusOK).Data(desc).Ok(c.Writer)
+	return nil
+}
+
+func (d DetectHTTPServiceV1) StopDetect(c *gin.Context) error {
+	var action SessionAction
+	if err := BindParams(&action, c.Params); err != nil {
+		return err
+	}
+
+	err := d.manager.StopSessionDetect(c.Request.Context(), action.SessionID)
+	if err != nil {
+		return status.Wrapper(http.StatusNotFound, err)
+	}
+
 	result.New[gin.H](http.StatusOK).Data(gin.H{"ok": true}).Ok(c.Writer)
 	return nil
 }
@@ -175,9 +473,9 @@ func (d DetectHTTPServiceV1) StartDetect(c *gin.Context) error {
 		return err
 	}
 
-	err := d.manager.StartSessionDetect(action.SessionID)
+	err := d.manager.StartSessionDetect(c.Request.Context(), action.SessionID)
 	if err != nil {
-		return status.Wrapper(http.StatusInternalServerError, err)
+		return status.Wrapper(http.StatusNotFound, err)
 	}
 	result.New[gin.H](http.StatusOK).Data(gin.H{"ok": true}).Ok(c.Writer)
 	return nil
@@ -189,8 +487,63 @@ func (d DetectHTTPServiceV1) RemoveSession(c *gin.Context) error {
 		return err
 	}
 
-	d.manager.RemoveSession(action.SessionID)
+	if err := d.manager.RemoveSession(c.Request.Context(), action.SessionID); err != nil {
+		if errors.Is(err, ErrSessionDeclared) {
+			return status.Wrapper(http.StatusConflict, err)
+		}
+		return status.Wrapper(http.StatusNotFound, err)
+	}
 
 	result.New[gin.H](http.StatusOK).Data(gin.H{"ok": true}).Ok(c.Writer)
 	return nil
 }
+
+func (d DetectHTTPServiceV1) BulkCreateSessions(c *gin.Context) error {
+	var reqs []CreateSessionReq
+	if c.ContentType() == "text/csv" {
+		var err error
+		if reqs, err = ParseSessionInventoryCSV(c.Request.Body); err != nil {
+			return status.Wrapper(http.StatusBadRequest, err)
+		}
+		if len(reqs) == 0 || len(reqs) > 500 {
+			return status.WrapperE(http.StatusBadRequest, "清单会话数需在 1-500 之间")
+		}
+	} else {
+		var req BulkCreateSessionReq
+		if err := Bind(&req, c.Request.Body); err != nil {
+			return err
+		}
+		reqs = req.Sessions
+	}
+
+	res := d.manager.BulkCreateSessions(c.Request.Context(), reqs)
+	result.New[BulkResult](http.StatusOK).Data(res).Ok(c.Writer)
+	return nil
+}
+
+func (d DetectHTTPServiceV1) BulkStartDetect(c *gin.Context) error {
+	return d.bulkApply(c, func(ctx context.Context, ids []string) BulkResult {
+		return d.manager.BulkSetDetectStatus(ctx, ids, true)
+	})
+}
+
+func (d DetectHTTPServiceV1) BulkStopDetect(c *gin.Context) error {
+	return d.bulkApply(c, func(ctx context.Context, ids []string) BulkResult {
+		return d.manager.BulkSetDetectStatus(ctx, ids, false)
+	})
+}
+
+func (d DetectHTTPServiceV1) BulkRemoveSessions(c *gin.Context) error {
+	return d.bulkApply(c, d.manager.BulkRemoveSessions)
+}
+
+func (d DetectHTTPServiceV1) bulkApply(c *gin.Context, apply func(ctx context.Context, ids []string) BulkResult) error {
+	var req BulkSelectorReq
+	if err := Bind(&req, c.Request.Body); err != nil {
+		return err
+	}
+	ids := d.manager.SelectSessionIDs(c.Request.Context(), req.IDs, req.Labels)
+	res := apply(c.Request.Context(), ids)
+	result.New[BulkResult](http.StatusOK).Data(res).Ok(c.Writer)
+	return nil
+}
@@ -0,0 +1,333 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	bolt "go.etcd.io/bbolt"
+	"go.uber.org/zap"
+	"go_client/pkg/auth"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// 识别事件类型
+const (
+	EventKindDetection = "detection" // 识别结果
+)
+
+const (
+	eventFlushInterval = time.Second // 识别事件批量写入存储的间隔
+	eventPurgeInterval = time.Hour   // 清理过期事件的间隔
+	maxPendingEvents   = 10000       // 写入失败时内存中最多保留的事件数，超出时丢弃最早的事件
+	maxEventCount      = 10000       // 查询最多统计的匹配事件数，超出后停止扫描
+)
+
+var (
+	eventRootBucket    = []byte("events")     // 事件 key -> 事件 JSON，key 按时间有序
+	eventSessionBucket = []byte("by-session") // 会话 ID -> 事件 key 索引
+)
+
+// StoredEvent 已存储的识别事件
+type StoredEvent struct {
+	ID   string `json:"id"`
+	Kind string `json:"kind"` // 事件类型 detection
+	DetectionEvent
+	Snapshot string `json:"snapshot,omitempty"` // 快照文件路径，可选
+}
+
+// EventFilter 识别事件查询条件，零值字段不过滤
+type EventFilter struct {
+	SessionID     string
+	Label         string  // 识别类别，事件中任一结果匹配即可
+	MinConfidence float64 // 最低置信度，与 Label 同时设置时需同一结果满足
+	From          time.Time
+	To            time.Time
+}
+
+func (f EventFilter) match(event StoredEvent, principal *auth.Principal) bool {
+	if !principal.CanAccess(event.Tenant) ||
+		(f.SessionID != "" && event.SessionID != f.SessionID) ||
+		(!f.From.IsZero() && event.Time.Before(f.From)) ||
+		(!f.To.IsZero() && !event.Time.Before(f.To)) {
+		return false
+	}
+	if f.Label == "" && f.MinConfidence <= 0 {
+		return true
+	}
+	return slices.ContainsFunc(event.Results, func(result DetectionResult) bool {
+		return (f.Label == "" || result.Label == f.Label) && result.Conf >= f.MinConfidence
+	})
+}
+
+// eventKey 事件 key：Unix 纳秒 + 序号，按时间有序
+func eventKey(t time.Time, seq uint64) []byte {
+	key := binary.BigEndian.AppendUint64(nil, uint64(t.UnixNano()))
+	return binary.BigEndian.AppendUint64(key, seq)
+}
+
+func eventTimeKey(t time.Time) []byte {
+	return binary.BigEndian.AppendUint64(nil, uint64(t.UnixNano()))
+}
+
+// EventStore 识别事件历史存储：批量写入本地 bbolt 数据库，按会话建立索引；nil 表示未开启
+type EventStore struct {
+	db        *bolt.DB
+	retention time.Duration // 事件保留时长，0 表示永久保留
+	logger    *zap.Logger
+	seq       atomic.Uint64
+
+	mu      sync.Mutex
+	pending []StoredEvent // 尚未写入存储的事件
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// OpenEventStore 打开识别事件数据库，目录不存在时创建
+func OpenEventStore(path string, retention time.Duration, logger *zap.Logger) (*EventStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("create event db directory: %w", err)
+	}
+	db, err := bolt.Open(path, 0o644, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("open event db: %w", err)
+	}
+	if err := db.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(eventRootBucket); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucketIfNotExists(eventSessionBucket)
+		return err
+	}); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("init event db: %w", err)
+	}
+
+	store := &EventStore{
+		db:        db,
+		retention: retention,
+		logger:    logger,
+		done:      make(chan struct{}),
+	}
+	store.wg.Add(1)
+	go store.loop()
+	return store, nil
+}
+
+// Record 保存有识别结果的识别事件，作为 DetectionEventHandler 在识别 goroutine 中调用，仅写内存
+func (e *EventStore) Record(event DetectionEvent) {
+	if e == nil || len(event.Results) == 0 {
+		return
+	}
+	e.Append(StoredEvent{Kind: EventKindDetection, DetectionEvent: event})
+}
+
+// Append 追加事件，写入失败仅记录日志
+func (e *EventStore) Append(event StoredEvent) {
+	if e == nil {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	event.ID = hex.EncodeToString(eventKey(event.Time, e.seq.Add(1)))
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.pending = append(e.pending, event)
+}
+
+func (e *EventStore) loop() {
+	defer e.wg.Done()
+	ticker := time.NewTicker(eventFlushInterval)
+	defer ticker.Stop()
+	lastPurge := time.Now()
+	for {
+		select {
+		case <-e.done:
+			return
+		case now := <-ticker.C:
+			if err := e.flush(); err != nil {
+				e.logger.Error("flush detection events failed, will retry", zap.Error(err))
+			}
+			if e.retention > 0 && now.Sub(lastPurge) >= eventPurgeInterval {
+				lastPurge = now
+				if err := e.purge(now.Add(-e.retention)); err != nil {
+					e.logger.Error("purge detection events failed", zap.Error(err))
+				}
+			}
+		}
+	}
+}
+
+// flush 将内存中的事件在一个事务内写入存储
+func (e *EventStore) flush() error {
+	e.mu.Lock()
+	pending := e.pending
+	e.pending = nil
+	e.mu.Unlock()
+	if len(pending) == 0 {
+		return nil
+	}
+
+	err := e.db.Update(func(tx *bolt.Tx) error {
+		root := tx.Bucket(eventRootBucket)
+		index := tx.Bucket(eventSessionBucket)
+		for _, event := range pending {
+			key, err := hex.DecodeString(event.ID)
+			if err != nil {
+				return err
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				return err
+			}
+			if err := root.Put(key, data); err != nil {
+				return err
+			}
+			session, err := index.CreateBucketIfNotExists([]byte(event.SessionID))
+			if err != nil {
+				return err
+			}
+			if err := session.Put(key, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		e.requeue(pending)
+	}
+	return err
+}
+
+// requeue 写入失败的事件放回队首等待下次写入，超出 maxPendingEvents 时丢弃最早的事件
+func (e *EventStore) requeue(events []StoredEvent) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.pending = append(events, e.pending...)
+	if dropped := len(e.pending) - maxPendingEvents; dropped > 0 {
+		e.pending = e.pending[dropped:]
+		e.logger.Warn("detection event store backlog full, oldest events dropped", zap.Int("dropped", dropped))
+	}
+}
+
+// purge 删除 cutoff 之前的事件及其会话索引
+func (e *EventStore) purge(cutoff time.Time) error {
+	end := eventTimeKey(cutoff)
+	return e.db.Update(func(tx *bolt.Tx) error {
+		root := tx.Bucket(eventRootBucket)
+		index := tx.Bucket(eventSessionBucket)
+		expired := make(map[string][][]byte)
+		c := root.Cursor()
+		for k, v := c.First(); k != nil && bytes.Compare(k, end) < 0; k, v = c.Next() {
+			var event StoredEvent
+			_ = json.Unmarshal(v, &event)
+			expired[event.SessionID] = append(expired[event.SessionID], k)
+		}
+		for sessionID, keys := range expired {
+			session := index.Bucket([]byte(sessionID))
+			for _, k := range keys {
+				if err := root.Delete(k); err != nil {
+					return err
+				}
+				if session != nil {
+					if err := session.Delete(k); err != nil {
+						return err
+					}
+				}
+			}
+			if session != nil {
+				if k, _ := session.Cursor().First(); k == nil {
+					if err := index.DeleteBucket([]byte(sessionID)); err != nil && !errors.Is(err, bolt.ErrBucketNotFound) {
+						return err
+					}
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// Query 按条件查询调用方可见的识别事件，按时间倒序，返回当前页与总数；limit 为 0 表示不分页。
+// 最多扫描 max(maxEventCount, offset+limit) 条匹配事件，total 超出时即为该上限
+func (e *EventStore) Query(ctx context.Context, filter EventFilter, offset, limit int) ([]StoredEvent, int, error) {
+	if e == nil {
+		return nil, 0, nil
+	}
+	if err := e.flush(); err != nil {
+		return nil, 0, fmt.Errorf("flush detection events: %w", err)
+	}
+	principal := auth.FromContext(ctx)
+
+	events := make([]StoredEvent, 0)
+	total := 0
+	scanLimit := max(maxEventCount, offset+limit)
+	err := e.db.View(func(tx *bolt.Tx) error {
+		root := tx.Bucket(eventRootBucket)
+		// 指定会话时遍历会话索引，否则遍历全部事件
+		c := root.Cursor()
+		if filter.SessionID != "" {
+			session := tx.Bucket(eventSessionBucket).Bucket([]byte(filter.SessionID))
+			if session == nil {
+				return nil
+			}
+			c = session.Cursor()
+		}
+
+		var k []byte
+		if filter.To.IsZero() {
+			k, _ = c.Last()
+		} else if k, _ = c.Seek(eventTimeKey(filter.To)); k == nil {
+			k, _ = c.Last()
+		} else {
+			k, _ = c.Prev()
+		}
+		start := eventTimeKey(filter.From)
+		for ; k != nil && total < scanLimit && (filter.From.IsZero() || bytes.Compare(k, start) >= 0); k, _ = c.Prev() {
+			var event StoredEvent
+			if err := json.Unmarshal(root.Get(k), &event); err != nil || !filter.match(event, principal) {
+				continue
+			}
+			if total >= offset && (limit <= 0 || len(events) < limit) {
+				events = append(events, event)
+			}
+			total++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("read detection events: %w", err)
+	}
+	return events, total, nil
+}
+
+// Close 停止后台写入，写入剩余事件并关闭数据库
+func (e *EventStore) Close() error {
+	if e == nil {
+		return nil
+	}
+	close(e.done)
+	e.wg.Wait()
+	err := e.flush()
+	if err != nil {
+		e.mu.Lock()
+		err = fmt.Errorf("%d detection events lost: %w", len(e.pending), err)
+		e.mu.Unlock()
+	}
+	return errors.Join(err, e.db.Close())
+}
+
+// DetectionEvents 查询调用方租户可见的识别事件，未开启事件存储时返回空列表；会话删除后仍可查询
+func (s *SessionManager) DetectionEvents(ctx context.Context, filter EventFilter, offset, limit int) ([]StoredEvent, int, error) {
+	return s.eventStore.Query(ctx, filter, offset, limit)
+}
@@ -0,0 +1,84 @@
+package engine
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"go_client/pkg/auth"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestEventStoreQuery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.db")
+	store, err := OpenEventStore(path, 0, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	store.Record(DetectionEvent{SessionID: "cam-12", Tenant: "t1", Time: start, Results: []DetectionResult{{Label: "person", Conf: 0.9}}})
+	store.Record(DetectionEvent{SessionID: "cam-12", Tenant: "t1", Time: start.Add(time.Minute), Results: []DetectionResult{{Label: "car", Conf: 0.4}, {Label: "person", Conf: 0.5}}})
+	store.Record(DetectionEvent{SessionID: "cam-12", Tenant: "t1", Time: start.Add(2 * time.Minute)}) // 无识别结果不保存
+	store.Record(DetectionEvent{SessionID: "cam-13", Tenant: "t2", Time: start.Add(3 * time.Minute), Results: []DetectionResult{{Label: "car", Conf: 0.8}}})
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 重新打开后仍可查询
+	store, err = OpenEventStore(path, 0, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	events, total, err := store.Query(ctx, EventFilter{SessionID: "cam-12"}, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 2 || !events[0].Time.Equal(start.Add(time.Minute)) || events[0].Kind != EventKindDetection {
+		t.Fatalf("unexpected events: total=%d events=%+v", total, events)
+	}
+
+	if _, total, _ := store.Query(ctx, EventFilter{Label: "person", MinConfidence: 0.6}, 0, 0); total != 1 {
+		t.Fatalf("label with min confidence: got %d events, want 1", total)
+	}
+	if events, total, _ := store.Query(ctx, EventFilter{Label: "car"}, 0, 1); total != 2 || len(events) != 1 || events[0].SessionID != "cam-13" {
+		t.Fatalf("paging: total=%d events=%+v", total, events)
+	}
+	if _, total, _ := store.Query(ctx, EventFilter{From: start.Add(30 * time.Second), To: start.Add(3 * time.Minute)}, 0, 0); total != 1 {
+		t.Fatalf("time range filter: got %d events, want 1", total)
+	}
+
+	other := auth.WithPrincipal(ctx, &auth.Principal{Tenant: "t2"})
+	if _, total, _ := store.Query(other, EventFilter{}, 0, 0); total != 1 {
+		t.Fatalf("tenant t2 should only see its own events, got %d", total)
+	}
+
+	if err := store.purge(start.Add(30 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if _, total, _ := store.Query(ctx, EventFilter{SessionID: "cam-12"}, 0, 0); total != 1 {
+		t.Fatalf("after purge: got %d events, want 1", total)
+	}
+}
+
+func TestEventStoreFlushFailureRequeues(t *testing.T) {
+	store, err := OpenEventStore(filepath.Join(t.TempDir(), "events.db"), 0, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	close(store.done)
+	store.wg.Wait()
+
+	store.Append(StoredEvent{Kind: EventKindDetection, DetectionEvent: DetectionEvent{SessionID: "cam-12"}})
+	if err := store.db.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.flush(); err == nil {
+		t.Fatal("expected flush to fail on closed db")
+	}
+	if len(store.pending) != 1 {
+		t.Fatalf("failed batch should be requeued, pending=%d", len(store.pending))
+	}
+}
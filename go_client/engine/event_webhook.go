@@ -0,0 +1,142 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go.uber.org/zap"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	webhookFlushInterval = time.Second     // 识别事件批量推送的间隔
+	webhookTimeout       = 5 * time.Second // 单次推送超时
+	maxWebhookBatch      = 500             // 单次推送的最大事件数
+	maxWebhookPending    = 10000           // 推送失败时内存中最多保留的事件数，超出时丢弃最早的事件
+)
+
+// EventWebhook 识别事件推送：按批 POST JSON 数组到下游地址，推送失败时保留事件等待重试；nil 表示未开启
+type EventWebhook struct {
+	url    string
+	client *http.Client
+	logger *zap.Logger
+
+	mu      sync.Mutex
+	pending []DetectionEvent // 尚未推送的事件
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewEventWebhook 创建识别事件推送并启动后台推送
+func NewEventWebhook(url string, logger *zap.Logger) *EventWebhook {
+	webhook := &EventWebhook{
+		url:    url,
+		client: &http.Client{Timeout: webhookTimeout},
+		logger: logger,
+		done:   make(chan struct{}),
+	}
+	webhook.wg.Add(1)
+	go webhook.loop()
+	return webhook
+}
+
+// Record 缓存有识别结果的识别事件，作为 DetectionEventHandler 在识别 goroutine 中调用，仅写内存
+func (w *EventWebhook) Record(event DetectionEvent) {
+	if w == nil || len(event.Results) == 0 {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.pending = append(w.pending, event)
+	if dropped := len(w.pending) - maxWebhookPending; dropped > 0 {
+		w.pending = w.pending[dropped:]
+		w.logger.Warn("detection event webhook backlog full, oldest events dropped", zap.Int("dropped", dropped))
+	}
+}
+
+func (w *EventWebhook) loop() {
+	defer w.wg.Done()
+	ticker := time.NewTicker(webhookFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-ticker.C:
+			if err := w.flush(); err != nil {
+				w.logger.Warn("push detection events failed, will retry", zap.String("url", w.url), zap.Error(err))
+			}
+		}
+	}
+}
+
+// flush 按批推送内存中的事件，失败的批次及其后的事件放回队首
+func (w *EventWebhook) flush() error {
+	w.mu.Lock()
+	pending := w.pending
+	w.pending = nil
+	w.mu.Unlock()
+
+	for len(pending) > 0 {
+		batch := pending[:min(len(pending), maxWebhookBatch)]
+		if err := w.post(batch); err != nil {
+			w.requeue(pending)
+			return err
+		}
+		pending = pending[len(batch):]
+	}
+	return nil
+}
+
+func (w *EventWebhook) post(events []DetectionEvent) error {
+	data, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// requeue 推送失败的事件放回队首等待下次推送，超出 maxWebhookPending 时丢弃最早的事件
+func (w *EventWebhook) requeue(events []DetectionEvent) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.pending = append(events, w.pending...)
+	if dropped := len(w.pending) - maxWebhookPending; dropped > 0 {
+		w.pending = w.pending[dropped:]
+		w.logger.Warn("detection event webhook backlog full, oldest events dropped", zap.Int("dropped", dropped))
+	}
+}
+
+// Close 停止后台推送并推送剩余事件
+func (w *EventWebhook) Close() error {
+	if w == nil {
+		return nil
+	}
+	close(w.done)
+	w.wg.Wait()
+	if err := w.flush(); err != nil {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		return fmt.Errorf("%d detection events not pushed: %w", len(w.pending), err)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package engine
+
+import (
+	"encoding/json"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestEventWebhookPushRetriesFailedBatch(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		received []DetectionEvent
+		fail     atomic.Bool
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var events []DetectionEvent
+		if err := json.NewDecoder(r.Body).Decode(&events); err != nil {
+			t.Error(err)
+		}
+		mu.Lock()
+		received = append(received, events...)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	webhook := NewEventWebhook(server.URL, zap.NewNop())
+	webhook.Record(DetectionEvent{SessionID: "cam-12", Results: []DetectionResult{{Label: "person", Conf: 0.9}}})
+	webhook.Record(DetectionEvent{SessionID: "cam-12"}) // 无识别结果不推送
+
+	// 推送失败时事件保留，恢复后重新推送
+	fail.Store(true)
+	if err := webhook.flush(); err == nil {
+		t.Fatal("expected push error")
+	}
+	fail.Store(false)
+	webhook.Record(DetectionEvent{SessionID: "cam-13", Results: []DetectionResult{{Label: "car", Conf: 0.8}}})
+	if err := webhook.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(received) != 2 || received[0].SessionID != "cam-12" || received[1].SessionID != "cam-13" {
+		t.Fatalf("unexpected events: %+v", received)
+	}
+}
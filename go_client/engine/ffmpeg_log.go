@@ -0,0 +1,155 @@
+package engine
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	ffmpegLogCapacity = 256  // 每个会话保留的 FFmpeg 日志行数
+	ffmpegLogMaxLine  = 4096 // 单行日志最大长度，超出部分截断
+)
+
+// FFmpeg 日志来源
+const (
+	FFmpegLogSourcePull = "pull" // 拉流 FFmpeg
+	FFmpegLogSourcePush = "push" // 推流 FFmpeg
+)
+
+// FFmpeg 已知错误类型
+const (
+	FFmpegErrUnauthorized      = "unauthorized"       // 鉴权失败
+	FFmpegErrNotFound          = "not_found"          // 流地址不存在
+	FFmpegErrConnectionRefused = "connection_refused" // 连接被拒绝
+	FFmpegErrUnreachable       = "unreachable"        // 主机不可达
+	FFmpegErrTimeout           = "timeout"            // 连接超时
+	FFmpegErrCodecNotFound     = "codec_not_found"    // 编解码器不存在
+	FFmpegErrInvalidData       = "invalid_data"       // 流数据无效
+)
+
+// ffmpegErrorPatterns FFmpeg stderr 已知错误匹配规则（小写匹配）
+var ffmpegErrorPatterns = []struct {
+	pattern string
+	code    string
+}{
+	{"401 unauthorized", FFmpegErrUnauthorized},
+	{"403 forbidden", FFmpegErrUnauthorized},
+	{"404 not found", FFmpegErrNotFound},
+	{"connection refused", FFmpegErrConnectionRefused},
+	{"no route to host", FFmpegErrUnreachable},
+	{"network is unreachable", FFmpegErrUnreachable},
+	{"connection timed out", FFmpegErrTimeout},
+	{"codec not found", FFmpegErrCodecNotFound},
+	{"unknown encoder", FFmpegErrCodecNotFound},
+	{"decoder not found", FFmpegErrCodecNotFound},
+	{"encoder not found", FFmpegErrCodecNotFound},
+	{"invalid data found when processing input", FFmpegErrInvalidData},
+}
+
+// matchFFmpegError 匹配 FFmpeg 已知错误，未匹配返回空字符串
+func matchFFmpegError(line string) string {
+	lower := strings.ToLower(line)
+	for i := range ffmpegErrorPatterns {
+		if strings.Contains(lower, ffmpegErrorPatterns[i].pattern) {
+			return ffmpegErrorPatterns[i].code
+		}
+	}
+	return ""
+}
+
+// FFmpegLogLine FFmpeg stderr 日志行
+type FFmpegLogLine struct {
+	Time   time.Time `json:"time"`
+	Source string    `json:"source"` // pull 拉流 push 推流
+	Line   string    `json:"line"`
+}
+
+// ffmpegLogBuffer 有界环形日志缓冲
+type ffmpegLogBuffer struct {
+	mu    sync.RWMutex
+	lines []FFmpegLogLine
+	next  int
+	full  bool
+}
+
+func (b *ffmpegLogBuffer) reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.lines = nil
+	b.next = 0
+	b.full = false
+}
+
+func (b *ffmpegLogBuffer) add(line FFmpegLogLine) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.lines == nil {
+		b.lines = make([]FFmpegLogLine, ffmpegLogCapacity)
+	}
+	b.lines[b.next] = line
+	b.next = (b.next + 1) % len(b.lines)
+	if b.next == 0 {
+		b.full = true
+	}
+}
+
+// Lines 按时间先后返回最近 limit 行日志，source 为空表示全部来源，limit <= 0 表示全部
+func (b *ffmpegLogBuffer) Lines(source string, limit int) []FFmpegLogLine {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	ordered := make([]FFmpegLogLine, 0, len(b.lines))
+	if b.full {
+		ordered = append(ordered, b.lines[b.next:]...)
+	}
+	ordered = append(ordered, b.lines[:b.next]...)
+
+	res := make([]FFmpegLogLine, 0, len(ordered))
+	for i := range ordered {
+		if source == "" || ordered[i].Source == source {
+			res = append(res, ordered[i])
+		}
+	}
+	if limit > 0 && len(res) > limit {
+		res = res[len(res)-limit:]
+	}
+	return res
+}
+
+// ffmpegLogWriter 按行切分 FFmpeg stderr 输出，exec 单 goroutine 写入，无需加锁
+type ffmpegLogWriter struct {
+	partial []byte
+	onLine  func(line string)
+}
+
+func (w *ffmpegLogWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		// FFmpeg 进度信息以 \r 结尾
+		i := bytes.IndexAny(p, "\r\n")
+		if i < 0 {
+			w.partial = append(w.partial, p...)
+			if len(w.partial) >= ffmpegLogMaxLine {
+				w.flush()
+			}
+			break
+		}
+		w.partial = append(w.partial, p[:i]...)
+		w.flush()
+		p = p[i+1:]
+	}
+	return n, nil
+}
+
+func (w *ffmpegLogWriter) flush() {
+	line := w.partial
+	if len(line) > ffmpegLogMaxLine {
+		line = line[:ffmpegLogMaxLine]
+	}
+	if text := strings.TrimSpace(string(line)); text != "" {
+		w.onLine(text)
+	}
+	w.partial = w.partial[:0]
+}
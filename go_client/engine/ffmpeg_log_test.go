@@ -0,0 +1,107 @@
+package engine
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestFFmpegLogWriterSplitsLines(t *testing.T) {
+	long := strings.Repeat("x", ffmpegLogMaxLine+10)
+	tests := []struct {
+		name   string
+		writes []string
+		want   []string
+	}{
+		{"single line", []string{"Input #0, rtsp\n"}, []string{"Input #0, rtsp"}},
+		{"partial line across writes", []string{"Connection ", "refused\nnext"}, []string{"Connection refused"}},
+		{"progress lines end with cr", []string{"frame=  1 fps=0.0\rframe=  2 fps=1.0\r"}, []string{"frame=  1 fps=0.0", "frame=  2 fps=1.0"}},
+		{"crlf and blank lines skipped", []string{"a\r\n\n  \nb\n"}, []string{"a", "b"}},
+		{"long line truncated", []string{long}, []string{long[:ffmpegLogMaxLine]}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			w := &ffmpegLogWriter{onLine: func(line string) { got = append(got, line) }}
+			for _, p := range tt.writes {
+				if n, err := w.Write([]byte(p)); n != len(p) || err != nil {
+					t.Fatalf("Write() = %d, %v", n, err)
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("lines = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFFmpegLogBufferRing(t *testing.T) {
+	tests := []struct {
+		name   string
+		count  int // 依次写入 pull/push 交替的日志行数
+		source string
+		limit  int
+		first  string // 返回的第一行
+		len    int
+	}{
+		{"empty", 0, "", 0, "", 0},
+		{"not full", 10, "", 0, "line 0", 10},
+		{"wraps keeps latest", ffmpegLogCapacity + 6, "", 0, "line 6", ffmpegLogCapacity},
+		{"limit returns latest", ffmpegLogCapacity + 6, "", 4, fmt.Sprintf("line %d", ffmpegLogCapacity+2), 4},
+		{"filter by source", ffmpegLogCapacity + 6, FFmpegLogSourcePush, 0, "line 7", ffmpegLogCapacity / 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b ffmpegLogBuffer
+			for i := 0; i < tt.count; i++ {
+				source := FFmpegLogSourcePull
+				if i%2 == 1 {
+					source = FFmpegLogSourcePush
+				}
+				b.add(FFmpegLogLine{Source: source, Line: fmt.Sprintf("line %d", i)})
+			}
+			lines := b.Lines(tt.source, tt.limit)
+			if len(lines) != tt.len {
+				t.Fatalf("len(lines) = %d, want %d", len(lines), tt.len)
+			}
+			if tt.len > 0 && lines[0].Line != tt.first {
+				t.Fatalf("first line = %q, want %q", lines[0].Line, tt.first)
+			}
+			if tt.len > 0 && lines[len(lines)-1].Line != fmt.Sprintf("line %d", tt.count-1) {
+				t.Fatalf("last line = %q", lines[len(lines)-1].Line)
+			}
+		})
+	}
+
+	var b ffmpegLogBuffer
+	b.add(FFmpegLogLine{Line: "before reset"})
+	b.reset()
+	if lines := b.Lines("", 0); len(lines) != 0 {
+		t.Fatalf("lines after reset = %+v", lines)
+	}
+}
+
+func TestMatchFFmpegError(t *testing.T) {
+	tests := []struct {
+		line string
+		code string
+	}{
+		{"[rtsp @ 0x55d] method DESCRIBE failed: 401 Unauthorized", FFmpegErrUnauthorized},
+		{"Server returned 403 Forbidden (access denied)", FFmpegErrUnauthorized},
+		{"method DESCRIBE failed: 404 Not Found", FFmpegErrNotFound},
+		{"Connection to tcp://10.0.0.1:554 failed: Connection refused", FFmpegErrConnectionRefused},
+		{"Connection to tcp://10.0.0.1:554 failed: No route to host", FFmpegErrUnreachable},
+		{"Network is unreachable", FFmpegErrUnreachable},
+		{"Connection to tcp://10.0.0.1:554 failed: Connection timed out", FFmpegErrTimeout},
+		{"Unknown encoder 'libx265'", FFmpegErrCodecNotFound},
+		{"rtsp://10.0.0.1/live: Invalid data found when processing input", FFmpegErrInvalidData},
+		{"frame=  100 fps= 25 q=28.0 size=  512kB", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := matchFFmpegError(tt.line); got != tt.code {
+			t.Errorf("matchFFmpegError(%q) = %q, want %q", tt.line, got, tt.code)
+		}
+	}
+}
@@ -2,14 +2,30 @@ package engine
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"go.uber.org/zap"
+	"go_client/config"
 	"io"
 	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 )
 
-func startFFmpegReader(rtsp string, width, height, fps int) (*exec.Cmd, io.Reader, error) {
-	cmd := exec.Command("ffmpeg",
+const pushFlushTimeout = 10 * time.Second // 关闭推流 stdin 后等待 FFmpeg 退出的最长时间
+
+// startFFmpegReader 开启拉流FFmpeg 拉流，stderr 日志写入 stderr
+func startFFmpegReader(rtsp string, width, height, fps int, isDebug bool, stderr io.Writer) (*exec.Cmd, io.Reader, error) {
+	args := make([]string, 0)
+	if isDebug {
+		args = append(args, "-loglevel", "debug") // 加入详细日志
+	} else {
+		args = append(args, "-loglevel", "error") // 只输出错误
+	}
+
+	args = append(args, []string{
 		"-rtsp_transport", "tcp",
 		"-i", rtsp,
 		"-an",
@@ -17,7 +33,13 @@ func startFFmpegReader(rtsp string, width, height, fps int) (*exec.Cmd, io.Reade
 		"-pix_fmt", "bgr24",
 		"-s", fmt.Sprintf("%dx%d", width, height),
 		"-r", fmt.Sprintf("%d", fps),
-		"-")
+		"-",
+	}...)
+
+	cmd := exec.Command("ffmpeg", args...)
+	// 👇 捕获 stderr 日志输出，由 exec 负责消费，防止阻塞
+	cmd.Stderr = stderr
+
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
 		return nil, nil, err
@@ -28,8 +50,8 @@ func startFFmpegReader(rtsp string, width, height, fps int) (*exec.Cmd, io.Reade
 	return cmd, stdout, nil
 }
 
-// startFFmpegPusher 开启推流FFmpeg 推流
-func startFFmpegPusher(width, height int, framerate float64, isDebug bool, rtmpURL string, _logger *zap.Logger) (*exec.Cmd, io.WriteCloser, error) {
+// startFFmpegPusher 开启推流FFmpeg 推流，按 profile 编码，stderr 日志写入 stderr
+func startFFmpegPusher(width, height int, framerate float64, isDebug bool, rtmpURL string, profile config.EncodingProfile, stderr io.Writer) (*exec.Cmd, io.WriteCloser, error) {
 	args := make([]string, 0)
 	if isDebug {
 		args = append(args, "-loglevel", "debug") // 加入详细日志
@@ -43,43 +65,165 @@ func startFFmpegPusher(width, height int, framerate float64, isDebug bool, rtmpU
 		"-video_size", fmt.Sprintf("%dx%d", width, height),
 		"-framerate", fmt.Sprintf("%.2f", framerate),
 		"-i", "-",
-		"-c:v", "libx264", "-pix_fmt", "yuv420p",
-		"-f", "flv", rtmpURL,
 	}...)
+	args = append(args, buildEncodeArgs(profile)...)
+	args = append(args, rtmpURL)
 
 	cmd := exec.Command("ffmpeg",
 		args...,
 	)
+	// 👇 捕获 stderr 日志输出，由 exec 负责消费，防止阻塞
+	cmd.Stderr = stderr
 
 	stdin, err := cmd.StdinPipe()
 	if err != nil {
 		return nil, nil, err
 	}
 
-	// 👇 捕获 stderr 日志输出
-	stderr, err := cmd.StderrPipe()
-	if err != nil {
+	if err := cmd.Start(); err != nil {
 		stdin.Close()
 		return nil, nil, err
 	}
 
-	// 根据 isDebug 决定日志处理
-	if isDebug {
-		go func() {
-			scanner := bufio.NewScanner(stderr)
-			for scanner.Scan() {
-				_logger.Info("FFmpeg: " + scanner.Text())
-			}
-		}()
-	} else {
-		// 即使不打印日志，也需要消费 stderr，防止阻塞
-		go io.Copy(io.Discard, stderr)
+	return cmd, stdin, nil
+}
+
+// waitFFmpeg 等待 FFmpeg 进程退出，超时后强制结束
+func waitFFmpeg(cmd *exec.Cmd, timeout time.Duration) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(timeout):
+		_ = cmd.Process.Kill()
+		return <-done
 	}
+}
 
-	if err := cmd.Start(); err != nil {
-		stdin.Close()
-		return nil, nil, err
+// buildEncodeArgs 根据编码配置生成推流 FFmpeg 输出参数（不含输出地址）
+func buildEncodeArgs(profile config.EncodingProfile) []string {
+	codec := profile.Codec
+	if codec == "" {
+		codec = config.CodecH264
 	}
 
-	return cmd, stdin, nil
+	args := []string{"-c:v", codec}
+	switch codec {
+	case config.CodecH264, config.CodecH265:
+		if profile.Preset != "" {
+			args = append(args, "-preset", profile.Preset)
+		}
+		if profile.Tune != "" {
+			args = append(args, "-tune", profile.Tune)
+		}
+		args = append(args, "-pix_fmt", "yuv420p")
+	case config.CodecVP8:
+		// 只接受 libvpx 的 deadline，x264 风格的预设（如 veryfast）忽略
+		deadline := ""
+		if slices.Contains(config.VP8Deadlines, profile.Preset) {
+			deadline = profile.Preset
+		}
+		if profile.Tune == "zerolatency" {
+			deadline = "realtime"
+			args = append(args, "-lag-in-frames", "0")
+		}
+		if deadline != "" {
+			args = append(args, "-deadline", deadline)
+		}
+		args = append(args, "-pix_fmt", "yuv420p")
+	case config.CodecMJPEG:
+		args = append(args, "-pix_fmt", "yuvj420p")
+	}
+
+	// 码率控制：设置码率时优先使用码率，否则使用质量因子
+	switch {
+	case profile.Bitrate > 0:
+		bitrate := fmt.Sprintf("%dk", profile.Bitrate)
+		args = append(args, "-b:v", bitrate, "-maxrate", bitrate, "-bufsize", fmt.Sprintf("%dk", profile.Bitrate*2))
+	case profile.CRF > 0 && codec == config.CodecMJPEG:
+		args = append(args, "-q:v", strconv.Itoa(profile.CRF))
+	case profile.CRF > 0 && codec == config.CodecVP8:
+		args = append(args, "-crf", strconv.Itoa(profile.CRF), "-b:v", "0")
+	case profile.CRF > 0:
+		args = append(args, "-crf", strconv.Itoa(profile.CRF))
+	}
+
+	if profile.GOP > 0 && codec != config.CodecMJPEG {
+		args = append(args, "-g", strconv.Itoa(profile.GOP))
+	}
+
+	// 推流输出分辨率与识别分辨率分离
+	if profile.OutputWidth > 0 && profile.OutputHeight > 0 {
+		args = append(args, "-vf", fmt.Sprintf("scale=%d:%d", profile.OutputWidth, profile.OutputHeight))
+	}
+
+	format := profile.Format
+	if format == "" {
+		format = "flv"
+	}
+	return append(args, "-f", format)
+}
+
+// checkFFmpegEncoders 启动时探测 FFmpeg 可用编码器并检查编码配置引用的编码器，探测失败返回 nil 表示不做限制
+func checkFFmpegEncoders(logger *zap.Logger, cfg *config.Config) map[string]bool {
+	encoders, err := probeFFmpegEncoders()
+	if err != nil {
+		logger.Warn("probe ffmpeg encoders failed, skip encoder check", zap.Error(err))
+		return nil
+	}
+
+	available := make([]string, 0, len(config.SupportedCodecs))
+	for _, codec := range config.SupportedCodecs {
+		if encoders[codec] {
+			available = append(available, codec)
+		}
+	}
+	logger.Info("🎞️ ffmpeg available encoders", zap.Strings("encoders", available))
+
+	profiles := map[string]config.EncodingProfile{"default": cfg.Engine.Encoding}
+	for name, profile := range cfg.Engine.EncodingProfiles {
+		profiles[name] = cfg.Engine.Encoding.Merge(profile)
+	}
+	for name, profile := range profiles {
+		codec := profile.Codec
+		if codec == "" {
+			codec = config.CodecH264
+		}
+		if !encoders[codec] {
+			logger.Warn("encoding profile uses an encoder not available in ffmpeg", zap.String("profile", name), zap.String("codec", codec))
+		}
+	}
+	return encoders
+}
+
+// probeFFmpegEncoders 执行 ffmpeg -encoders 获取可用的视频编码器
+func probeFFmpegEncoders() (map[string]bool, error) {
+	out, err := exec.Command("ffmpeg", "-hide_banner", "-encoders").Output()
+	if err != nil {
+		return nil, fmt.Errorf("ffmpeg -encoders: %w", err)
+	}
+
+	// 输出格式：
+	//  V..... = Video
+	//  ------
+	//  V....D libx264              libx264 H.264 / AVC / MPEG-4 AVC ...
+	encoders := make(map[string]bool)
+	started := false
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !started {
+			started = strings.HasPrefix(line, "---")
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && strings.HasPrefix(fields[0], "V") {
+			encoders[fields[1]] = true
+		}
+	}
+	return encoders, scanner.Err()
 }
@@ -0,0 +1,41 @@
+package engine
+
+import (
+	"go_client/config"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestBuildEncodeArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile config.EncodingProfile
+		want    string
+	}{
+		{"default", config.EncodingProfile{}, "-c:v libx264 -pix_fmt yuv420p -f flv"},
+		{"h264 preset tune crf", config.EncodingProfile{Preset: "veryfast", Tune: "zerolatency", CRF: 23, GOP: 50},
+			"-c:v libx264 -preset veryfast -tune zerolatency -pix_fmt yuv420p -crf 23 -g 50 -f flv"},
+		{"h265 bitrate over crf", config.EncodingProfile{Codec: config.CodecH265, Preset: "fast", Bitrate: 2000, CRF: 28},
+			"-c:v libx265 -preset fast -pix_fmt yuv420p -b:v 2000k -maxrate 2000k -bufsize 4000k -f flv"},
+		{"vp8 deadline preset", config.EncodingProfile{Codec: config.CodecVP8, Preset: "good", CRF: 10, Format: "webm"},
+			"-c:v libvpx -deadline good -pix_fmt yuv420p -crf 10 -b:v 0 -f webm"},
+		{"vp8 ignores x264 preset", config.EncodingProfile{Codec: config.CodecVP8, Preset: "veryfast", Format: "webm"},
+			"-c:v libvpx -pix_fmt yuv420p -f webm"},
+		{"vp8 zerolatency forces realtime", config.EncodingProfile{Codec: config.CodecVP8, Preset: "best", Tune: "zerolatency", Format: "webm"},
+			"-c:v libvpx -lag-in-frames 0 -deadline realtime -pix_fmt yuv420p -f webm"},
+		{"mjpeg quality without gop", config.EncodingProfile{Codec: config.CodecMJPEG, Preset: "veryfast", CRF: 5, GOP: 25, Format: "mpjpeg"},
+			"-c:v mjpeg -pix_fmt yuvj420p -q:v 5 -f mpjpeg"},
+		{"output scale", config.EncodingProfile{OutputWidth: 1280, OutputHeight: 720},
+			"-c:v libx264 -pix_fmt yuv420p -vf scale=1280:720 -f flv"},
+		{"output scale needs both sides", config.EncodingProfile{OutputWidth: 1280},
+			"-c:v libx264 -pix_fmt yuv420p -f flv"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := buildEncodeArgs(tt.profile); !slices.Equal(got, strings.Fields(tt.want)) {
+				t.Fatalf("buildEncodeArgs() = %q, want %q", strings.Join(got, " "), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"go_client/pb"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	healthCheckTimeout  = 3 * time.Second  // 单项依赖检查超时
+	healthCheckInterval = 10 * time.Second // gRPC Health 状态刷新间隔
+)
+
+// 依赖检查项
+const (
+	HealthCheckFFmpeg = "ffmpeg"
+	HealthCheckAI     = "ai"
+	HealthCheckRTMP   = "rtmp"
+	HealthCheckLogDir = "log-dir"
+)
+
+// HealthCheckResult 单项依赖检查结果
+type HealthCheckResult struct {
+	Name     string `json:"name"`
+	OK       bool   `json:"ok"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+}
+
+// ReadinessReport 就绪检查报告，任一依赖失败即未就绪
+type ReadinessReport struct {
+	Ready  bool                `json:"ready"`
+	Checks []HealthCheckResult `json:"checks"`
+}
+
+// HealthChecker 依赖健康检查，配置热更新后使用最新配置
+type HealthChecker struct {
+	manager *SessionManager
+}
+
+func NewHealthChecker(manager *SessionManager) *HealthChecker {
+	return &HealthChecker{manager: manager}
+}
+
+// Readiness 并发检查 ffmpeg、识别服务、RTMP 前缀主机解析与日志目录可写
+func (h *HealthChecker) Readiness(ctx context.Context) ReadinessReport {
+	cfg := h.manager.Config()
+	checks := []struct {
+		name  string
+		check func(ctx context.Context) error
+	}{
+		{HealthCheckFFmpeg, func(context.Context) error { return checkFFmpegOnPath() }},
+		{HealthCheckAI, func(ctx context.Context) error {
+			return probeAIService(ctx, cfg.Engine.DetectAIURL, cfg.Engine.UvicornSocket, cfg.Engine.SocketPath)
+		}},
+		{HealthCheckRTMP, func(ctx context.Context) error { return resolveURLHost(ctx, *h.manager.pushUrlInternalPre.Load()) }},
+		{HealthCheckLogDir, func(context.Context) error { return checkDirWritable(filepath.Dir(cfg.Logger.LogPath)) }},
+	}
+
+	report := ReadinessReport{Ready: true, Checks: make([]HealthCheckResult, len(checks))}
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+			defer cancel()
+
+			start := time.Now()
+			err := c.check(ctx)
+			report.Checks[i] = HealthCheckResult{Name: c.name, OK: err == nil, Duration: time.Since(start).String()}
+			if err != nil {
+				report.Checks[i].Error = err.Error()
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, c := range report.Checks {
+		report.Ready = report.Ready && c.OK
+	}
+	return report
+}
+
+// Redacted 去除检查错误详情，供未认证的探测接口返回
+func (r ReadinessReport) Redacted() ReadinessReport {
+	checks := make([]HealthCheckResult, len(r.Checks))
+	for i, c := range r.Checks {
+		c.Error = ""
+		checks[i] = c
+	}
+	r.Checks = checks
+	return r
+}
+
+// WatchGRPCHealth 定期执行就绪检查并更新 gRPC Health 服务状态，ctx 结束后标记为 NOT_SERVING
+func (h *HealthChecker) WatchGRPCHealth(ctx context.Context, srv *health.Server, logger *zap.Logger) {
+	update := func() {
+		report := h.Readiness(ctx)
+		status := healthpb.HealthCheckResponse_SERVING
+		if !report.Ready {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+			logger.Warn("readiness check failed", zap.Any("checks", report.Checks))
+		}
+		srv.SetServingStatus("", status)
+		srv.SetServingStatus(pb.DetectService_ServiceDesc.ServiceName, status)
+	}
+
+	go func() {
+		ticker := time.NewTicker(healthCheckInterval)
+		defer ticker.Stop()
+		update()
+		for {
+			select {
+			case <-ctx.Done():
+				srv.Shutdown()
+				return
+			case <-ticker.C:
+				update()
+			}
+		}
+	}()
+}
+
+func checkFFmpegOnPath() error {
+	_, err := exec.LookPath("ffmpeg")
+	return err
+}
+
+// probeAIService 探测识别服务是否响应，任何非 5xx 响应均视为可用（识别接口仅支持 POST）
+func probeAIService(ctx context.Context, aiURL string, uvicornSocket bool, socketPath string) error {
+	client := http.DefaultClient
+	if uvicornSocket {
+		// socket 路径可热更新，每次探测新建 Transport，关闭长连接避免空闲连接泄漏
+		client = &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					var d net.Dialer
+					return d.DialContext(ctx, "unix", socketPath)
+				},
+				DisableKeepAlives: true,
+			},
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, aiURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("ai service responded %s", resp.Status)
+	}
+	return nil
+}
+
+// resolveURLHost 解析推流前缀中的主机名
+func resolveURLHost(ctx context.Context, raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	if u.Hostname() == "" {
+		return errors.New("push url prefix has no host")
+	}
+	_, err = net.DefaultResolver.LookupHost(ctx, u.Hostname())
+	return err
+}
+
+func checkDirWritable(dir string) error {
+	f, err := os.CreateTemp(dir, ".health-check-*")
+	if err != nil {
+		return err
+	}
+	_ = f.Close()
+	return os.Remove(f.Name())
+}
@@ -0,0 +1,45 @@
+package engine
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go_client/pkg/result"
+	"net/http"
+)
+
+type HealthHTTPService interface {
+	Liveness(c *gin.Context) error  // 进程存活检查
+	Readiness(c *gin.Context) error // 依赖就绪检查，未就绪返回 503
+}
+
+// RegisterHealthHTTPService 注册健康检查路由，供编排系统探测，不经过认证，不返回依赖错误详情
+func RegisterHealthHTTPService(eng *gin.Engine, srv HealthHTTPService) {
+	eng.GET("/healthz", WrapHandler(srv.Liveness))
+	eng.GET("/readyz", WrapHandler(srv.Readiness))
+}
+
+// HealthHTTPServiceV1 健康检查http服务
+type HealthHTTPServiceV1 struct {
+	checker *HealthChecker
+	logger  *zap.Logger
+}
+
+func NewHealthHTTPServiceV1(checker *HealthChecker, logger *zap.Logger) HealthHTTPService {
+	return HealthHTTPServiceV1{checker, logger}
+}
+
+func (h HealthHTTPServiceV1) Liveness(c *gin.Context) error {
+	result.Any(http.StatusOK).Message("ok").Ok(c.Writer)
+	return nil
+}
+
+func (h HealthHTTPServiceV1) Readiness(c *gin.Context) error {
+	report := h.checker.Readiness(c.Request.Context())
+	if !report.Ready {
+		h.logger.Warn("readiness check failed", zap.Any("checks", report.Checks))
+		result.New[ReadinessReport](http.StatusServiceUnavailable).Data(report.Redacted()).Message("not ready").Err(c.Writer)
+		return nil
+	}
+	result.New[ReadinessReport](http.StatusOK).Data(report.Redacted()).Ok(c.Writer)
+	return nil
+}
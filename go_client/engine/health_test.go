@@ -0,0 +1,33 @@
+package engine
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestProbeAIService(t *testing.T) {
+	var code atomic.Int32
+	code.Store(http.StatusMethodNotAllowed)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(int(code.Load()))
+	}))
+	defer srv.Close()
+
+	// 识别接口仅支持 POST，405 视为可用
+	if err := probeAIService(context.Background(), srv.URL+"/detect", false, ""); err != nil {
+		t.Fatalf("expected ai service ready, got %v", err)
+	}
+
+	code.Store(http.StatusBadGateway)
+	if err := probeAIService(context.Background(), srv.URL+"/detect", false, ""); err == nil {
+		t.Fatal("expected error on 5xx response")
+	}
+
+	srv.Close()
+	if err := probeAIService(context.Background(), srv.URL+"/detect", false, ""); err == nil {
+		t.Fatal("expected error when ai service is down")
+	}
+}
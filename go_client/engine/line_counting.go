@@ -0,0 +1,306 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"github.com/robfig/cron/v3"
+	"image"
+	"math"
+	"slices"
+	"sort"
+	"time"
+)
+
+var ErrInvalidLineCounting = errors.New("invalid line counting")
+
+const (
+	trackMaxMisses = 5 // 目标连续未匹配的识别次数超过该值后结束跟踪
+	maxCountLines  = 16
+)
+
+// LineCounting 虚拟线计数：跟踪识别目标中心点，按类别统计穿越每条线的进出次数
+type LineCounting struct {
+	Lines    []CountingLine `json:"lines"`
+	Reset    string         `json:"reset,omitempty"`    // 计数清零 cron 表达式（5 段或 @daily 等），为空不自动清零
+	Timezone string         `json:"timezone,omitempty"` // reset 使用的 IANA 时区，默认服务器本地时区
+}
+
+// CountingLine 虚拟线，坐标为识别分辨率下的像素坐标，须位于画面内；沿 (x1,y1)->(x2,y2) 方向看，从左侧穿越到右侧为 in，反之为 out
+type CountingLine struct {
+	Name   string   `json:"name"`
+	X1     int      `json:"x1"`
+	Y1     int      `json:"y1"`
+	X2     int      `json:"x2"`
+	Y2     int      `json:"y2"`
+	Labels []string `json:"labels,omitempty"` // 计数的识别类别，为空表示全部
+}
+
+// CrossCount 进出次数
+type CrossCount struct {
+	In  int64 `json:"in"`
+	Out int64 `json:"out"`
+}
+
+// LineCount 单条线的计数
+type LineCount struct {
+	Name string `json:"name"`
+	CrossCount
+	Labels map[string]CrossCount `json:"labels"` // 按类别计数
+}
+
+// LineCounts 会话当前的计数
+type LineCounts struct {
+	Since     time.Time   `json:"since"`               // 本轮计数开始时间
+	NextReset *time.Time  `json:"nextReset,omitempty"` // 下一次自动清零时间
+	Lines     []LineCount `json:"lines"`
+}
+
+// lineCounter 已解析的计数配置、目标跟踪与计数
+type lineCounter struct {
+	spec      LineCounting
+	location  *time.Location
+	reset     cron.Schedule // nil 表示不自动清零
+	tracker   centroidTracker
+	since     time.Time
+	nextReset time.Time
+	counts    []map[string]*CrossCount // 按线、类别计数
+	version   uint64                   // 计数变化或清零时递增，用于判断画面叠加文字是否需要重建
+}
+
+// checkBounds 校验线的端点位于 width×height 的画面内，尺寸未知（0）时不校验
+func (c *LineCounting) checkBounds(width, height int) error {
+	if c == nil || width <= 0 || height <= 0 {
+		return nil
+	}
+	for i, line := range c.Lines {
+		for _, p := range []image.Point{image.Pt(line.X1, line.Y1), image.Pt(line.X2, line.Y2)} {
+			if p.X < 0 || p.Y < 0 || p.X >= width || p.Y >= height {
+				return fmt.Errorf("%w: lines[%d]: point (%d,%d) is outside the %dx%d frame", ErrInvalidLineCounting, i, p.X, p.Y, width, height)
+			}
+		}
+	}
+	return nil
+}
+
+// compile 校验并解析计数配置，nil 或没有线时返回 nil
+func (c *LineCounting) compile(now time.Time) (*lineCounter, error) {
+	if c == nil || len(c.Lines) == 0 {
+		return nil, nil
+	}
+	if len(c.Lines) > maxCountLines {
+		return nil, fmt.Errorf("%w: at most %d lines, got %d", ErrInvalidLineCounting, maxCountLines, len(c.Lines))
+	}
+	counter := &lineCounter{spec: *c, location: time.Local, tracker: centroidTracker{tracks: make(map[int]*track)}}
+	for i, line := range c.Lines {
+		if line.Name == "" {
+			return nil, fmt.Errorf("%w: lines[%d]: name is required", ErrInvalidLineCounting, i)
+		}
+		if slices.ContainsFunc(c.Lines[:i], func(l CountingLine) bool { return l.Name == line.Name }) {
+			return nil, fmt.Errorf("%w: lines[%d]: duplicate name %q", ErrInvalidLineCounting, i, line.Name)
+		}
+		if line.X1 == line.X2 && line.Y1 == line.Y2 {
+			return nil, fmt.Errorf("%w: lines[%d]: start and end points are the same", ErrInvalidLineCounting, i)
+		}
+	}
+	if c.Timezone != "" {
+		location, err := time.LoadLocation(c.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("%w: timezone: %w", ErrInvalidLineCounting, err)
+		}
+		counter.location = location
+	}
+	if c.Reset != "" {
+		reset, err := cronParser.Parse(c.Reset)
+		if err != nil {
+			return nil, fmt.Errorf("%w: reset: %w", ErrInvalidLineCounting, err)
+		}
+		counter.reset = reset
+	}
+	counter.resetCounts(now)
+	return counter, nil
+}
+
+// resetCounts 清零计数并计算下一次自动清零时间，不影响目标跟踪
+func (l *lineCounter) resetCounts(now time.Time) {
+	l.version++
+	l.since = now
+	l.counts = make([]map[string]*CrossCount, len(l.spec.Lines))
+	for i := range l.counts {
+		l.counts[i] = make(map[string]*CrossCount)
+	}
+	if l.reset != nil {
+		l.nextReset = l.reset.Next(now.In(l.location))
+	}
+}
+
+// resetIfDue 到达自动清零时间时清零计数，识别暂停期间也需在读取计数前调用
+func (l *lineCounter) resetIfDue(now time.Time) {
+	if l.reset != nil && !now.Before(l.nextReset) {
+		l.resetCounts(now)
+	}
+}
+
+// observe 用一次识别结果更新目标跟踪，统计穿越虚拟线的目标
+func (l *lineCounter) observe(results []DetectionResult, now time.Time) {
+	l.resetIfDue(now)
+	for _, move := range l.tracker.update(results) {
+		for i, line := range l.spec.Lines {
+			if len(line.Labels) > 0 && !slices.Contains(line.Labels, move.label) {
+				continue
+			}
+			in, crossed := line.crossed(move.from, move.to)
+			if !crossed {
+				continue
+			}
+			count, ok := l.counts[i][move.label]
+			if !ok {
+				count = &CrossCount{}
+				l.counts[i][move.label] = count
+			}
+			if in {
+				count.In++
+			} else {
+				count.Out++
+			}
+			l.version++
+		}
+	}
+}
+
+// lineTotals 单条线全部类别的进出次数之和
+func (l *lineCounter) lineTotals(i int) CrossCount {
+	var total CrossCount
+	for _, count := range l.counts[i] {
+		total.In += count.In
+		total.Out += count.Out
+	}
+	return total
+}
+
+func (l *lineCounter) snapshot() LineCounts {
+	counts := LineCounts{Since: l.since, Lines: make([]LineCount, len(l.spec.Lines))}
+	if l.reset != nil {
+		nextReset := l.nextReset
+		counts.NextReset = &nextReset
+	}
+	for i, line := range l.spec.Lines {
+		lineCount := LineCount{Name: line.Name, Labels: make(map[string]CrossCount, len(l.counts[i]))}
+		for label, count := range l.counts[i] {
+			lineCount.Labels[label] = *count
+			lineCount.In += count.In
+			lineCount.Out += count.Out
+		}
+		counts.Lines[i] = lineCount
+	}
+	return counts
+}
+
+// side 点 p 位于线的哪一侧：沿线方向看（图像坐标 y 轴向下）右侧为正，左侧为负，线上为 0
+func (line CountingLine) side(p image.Point) int {
+	cross := (line.X2-line.X1)*(p.Y-line.Y1) - (line.Y2-line.Y1)*(p.X-line.X1)
+	switch {
+	case cross > 0:
+		return 1
+	case cross < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// crossed 目标从 from 移动到 to 是否穿越线段，in 表示从左侧到右侧；线上的点视为右侧，避免落在线上的目标漏计
+func (line CountingLine) crossed(from, to image.Point) (in bool, ok bool) {
+	before, after := line.side(from) >= 0, line.side(to) >= 0
+	if before == after {
+		return false, false
+	}
+	// 线段两端点需位于移动轨迹两侧，否则轨迹只穿过线段所在直线的延长线
+	move := CountingLine{X1: from.X, Y1: from.Y, X2: to.X, Y2: to.Y}
+	if move.side(image.Pt(line.X1, line.Y1))*move.side(image.Pt(line.X2, line.Y2)) > 0 {
+		return false, false
+	}
+	return after, true
+}
+
+// track 被跟踪的目标
+type track struct {
+	label  string
+	center image.Point
+	misses int // 连续未匹配的识别次数
+}
+
+// trackMove 目标在两次识别之间的移动
+type trackMove struct {
+	label    string
+	from, to image.Point
+}
+
+// centroidTracker 按中心点距离关联相邻两次识别结果中的同类目标
+type centroidTracker struct {
+	nextID int
+	tracks map[int]*track
+}
+
+// clear 结束全部跟踪，之后的识别结果都作为新目标，不会与之前的位置关联产生穿越
+func (t *centroidTracker) clear() {
+	clear(t.tracks)
+}
+
+// update 关联本次识别结果，返回已有目标的移动；匹配距离上限为检测框的长边，未匹配的结果作为新目标
+func (t *centroidTracker) update(results []DetectionResult) []trackMove {
+	type candidate struct {
+		trackID, result int
+		distance        float64
+	}
+	centers := make([]image.Point, len(results))
+	candidates := make([]candidate, 0)
+	for i, r := range results {
+		centers[i] = image.Pt((r.X1+r.X2)/2, (r.Y1+r.Y2)/2)
+		maxDistance := float64(max(abs(r.X2-r.X1), abs(r.Y2-r.Y1)))
+		for id, tr := range t.tracks {
+			if tr.label != r.Label {
+				continue
+			}
+			d := centers[i].Sub(tr.center)
+			if distance := math.Hypot(float64(d.X), float64(d.Y)); distance <= maxDistance {
+				candidates = append(candidates, candidate{trackID: id, result: i, distance: distance})
+			}
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].distance < candidates[j].distance })
+
+	moves := make([]trackMove, 0)
+	matchedTracks := make(map[int]bool)
+	matchedResults := make([]bool, len(results))
+	for _, c := range candidates {
+		if matchedTracks[c.trackID] || matchedResults[c.result] {
+			continue
+		}
+		matchedTracks[c.trackID], matchedResults[c.result] = true, true
+		tr := t.tracks[c.trackID]
+		moves = append(moves, trackMove{label: tr.label, from: tr.center, to: centers[c.result]})
+		tr.center, tr.misses = centers[c.result], 0
+	}
+	for id, tr := range t.tracks {
+		if matchedTracks[id] {
+			continue
+		}
+		if tr.misses++; tr.misses > trackMaxMisses {
+			delete(t.tracks, id)
+		}
+	}
+	for i, r := range results {
+		if !matchedResults[i] {
+			t.nextID++
+			t.tracks[t.nextID] = &track{label: r.Label, center: centers[i]}
+		}
+	}
+	return moves
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
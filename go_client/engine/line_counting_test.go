@@ -0,0 +1,61 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func box(label string, cx, cy int) DetectionResult {
+	return DetectionResult{X1: cx - 20, Y1: cy - 40, X2: cx + 20, Y2: cy + 40, Label: label, Conf: 0.9}
+}
+
+func TestLineCounterObserve(t *testing.T) {
+	now := time.Date(2024, 5, 1, 23, 59, 0, 0, time.UTC)
+	counting := &LineCounting{
+		// 从左向右的水平线，向下穿越为 in
+		Lines:    []CountingLine{{Name: "door", X1: 0, Y1: 300, X2: 640, Y2: 300, Labels: []string{"person"}}},
+		Reset:    "@daily",
+		Timezone: "UTC",
+	}
+	counter, err := counting.compile(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 人向下走并恰好停在线上，车辆不计数；另一人从线段外侧经过
+	frames := [][]DetectionResult{
+		{box("person", 100, 260), box("car", 400, 260), box("person", 700, 260)},
+		{box("person", 100, 300), box("car", 400, 320), box("person", 700, 320)},
+		{box("person", 100, 340)},
+		{box("person", 100, 280)},
+	}
+	for _, results := range frames {
+		counter.observe(results, now)
+	}
+	door := counter.snapshot().Lines[0]
+	if door.In != 1 || door.Out != 1 || door.Labels["person"] != (CrossCount{In: 1, Out: 1}) {
+		t.Fatalf("unexpected counts: %+v", door)
+	}
+	if _, ok := door.Labels["car"]; ok {
+		t.Fatalf("car should not be counted: %+v", door)
+	}
+
+	// 识别暂停期间到达清零时间，读取前清零
+	counter.resetIfDue(now.Add(time.Minute))
+	counts := counter.snapshot()
+	if counts.Lines[0].In != 0 || counts.NextReset == nil || !counts.NextReset.Equal(now.Add(time.Minute).Add(24*time.Hour)) {
+		t.Fatalf("unexpected counts after reset: %+v", counts)
+	}
+
+	invalid := &LineCounting{Lines: []CountingLine{{Name: "a", X1: 1, Y1: 1, X2: 1, Y2: 1}}}
+	if _, err := invalid.compile(now); !errors.Is(err, ErrInvalidLineCounting) {
+		t.Fatalf("expected ErrInvalidLineCounting, got %v", err)
+	}
+	if err := counting.checkBounds(640, 360); !errors.Is(err, ErrInvalidLineCounting) {
+		t.Fatalf("expected line outside 640x360 frame to be rejected, got %v", err)
+	}
+	if err := counting.checkBounds(1280, 720); err != nil {
+		t.Fatalf("expected line inside 1280x720 frame, got %v", err)
+	}
+}
@@ -1,9 +1,9 @@
 package engine
 
 import (
-	"fmt"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
+	"go_client/pkg/auth"
 	"go_client/pkg/result"
 	"net/http"
 	"runtime/debug"
@@ -13,24 +13,65 @@ import (
 func RecoveryMiddleware(logger *zap.Logger) gin.RecoveryFunc {
 	return func(c *gin.Context, err any) {
 		response := result.New[any](http.StatusServiceUnavailable)
-		logger.Error(fmt.Sprintf("Recovery panic:%s", string(debug.Stack())))
+		logger.Error("Recovery panic", zap.Any("error", err), zap.ByteString("stack", debug.Stack()))
 		response.Message("Service Unavailable").Err(c.Writer)
 		c.Abort()
 	}
 }
 
+// AuthMiddleware 校验 X-API-Key 或 Authorization Bearer JWT，并将调用方身份写入请求上下文
+func AuthMiddleware(authenticator *auth.Authenticator, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, err := authenticator.Authenticate(c.GetHeader("X-API-Key"), c.GetHeader("Authorization"))
+		if err != nil {
+			logger.Debug("auth failed",
+				zap.String("client_ip", c.ClientIP()),
+				zap.String("method", c.Request.Method),
+				zap.String("uri", c.Request.RequestURI),
+				zap.Error(err),
+			)
+			result.Any(http.StatusUnauthorized).Message(err.Error()).Err(c.Writer)
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(auth.WithPrincipal(c.Request.Context(), principal))
+		c.Next()
+	}
+}
+
+// AdminOnlyMiddleware 仅允许管理员访问，需位于 AuthMiddleware 之后；未开启认证时不做限制
+func AdminOnlyMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if principal := auth.FromContext(c.Request.Context()); principal != nil && !principal.Admin {
+			result.Any(http.StatusForbidden).Message("admin only").Err(c.Writer)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// AuditMiddleware 将客户端 IP 与 X-Audit-Reason 写入请求上下文，供会话审计记录
+func AuditMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(withAuditInfo(c.Request.Context(), c.ClientIP(), c.GetHeader("X-Audit-Reason")))
+		c.Next()
+	}
+}
+
 func LoggerMiddleware(logger *zap.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now().Local()
 		c.Next()
 		now := time.Now().Local()
 
-		logger.Debug(fmt.Sprintf("|%d| %s\t%s: %s %s",
-			c.Writer.Status(),
-			c.ClientIP(),
-			c.Request.Method,
-			c.Request.RequestURI,
-			now.Sub(start).String()),
+		logger.Debug("http request",
+			zap.Int("status", c.Writer.Status()),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("method", c.Request.Method),
+			zap.String("uri", c.Request.RequestURI),
+			zap.Duration("latency", now.Sub(start)),
 		)
 
 	}
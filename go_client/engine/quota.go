@@ -0,0 +1,66 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrQuotaExceeded = errors.New("session quota exceeded")
+)
+
+// sessionCost 会话成本：每秒需处理的像素数（宽×高×帧率）
+func sessionCost(width, height, framerate int) int64 {
+	return int64(width) * int64(height) * int64(framerate)
+}
+
+// cost 会话当前成本
+func (s *Session) cost() int64 {
+	s.pipeMu.RLock()
+	defer s.pipeMu.RUnlock()
+	return sessionCost(s.width, s.height, s.framerate)
+}
+
+// sessionUsage 会话占用：数量与成本
+type sessionUsage struct {
+	sessions  int
+	pixelRate int64
+}
+
+// admit 准入检查：统计未结束会话（排除 excludeID）的全局与租户占用，加上新增成本后是否超出配额
+// 调用方需持有 admitMu，避免并发创建同时通过检查
+func (s *SessionManager) admit(tenant, excludeID string, cost int64) error {
+	quota := s.Config().Engine.Quota
+	tenantQuota := quota.TenantQuota(tenant)
+
+	var total, owned sessionUsage
+	s.sessions.Range(func(key string, _session *Session) bool {
+		if key == excludeID || _session.State().IsTerminal() {
+			return true
+		}
+		c := _session.cost()
+		total.sessions++
+		total.pixelRate += c
+		if _session.tenant == tenant {
+			owned.sessions++
+			owned.pixelRate += c
+		}
+		return true
+	})
+
+	newSession := 1
+	if excludeID != "" {
+		newSession = 0
+	}
+	switch {
+	case quota.MaxSessions > 0 && total.sessions+newSession > quota.MaxSessions:
+		return fmt.Errorf("%w: max sessions %d reached", ErrQuotaExceeded, quota.MaxSessions)
+	case quota.MaxPixelRate > 0 && total.pixelRate+cost > quota.MaxPixelRate:
+		return fmt.Errorf("%w: pixel rate %d + %d exceeds capacity %d", ErrQuotaExceeded, total.pixelRate, cost, quota.MaxPixelRate)
+	case tenantQuota.MaxSessions > 0 && owned.sessions+newSession > tenantQuota.MaxSessions:
+		return fmt.Errorf("%w: tenant %q max sessions %d reached", ErrQuotaExceeded, tenant, tenantQuota.MaxSessions)
+	case tenantQuota.MaxPixelRate > 0 && owned.pixelRate+cost > tenantQuota.MaxPixelRate:
+		return fmt.Errorf("%w: tenant %q pixel rate %d + %d exceeds quota %d", ErrQuotaExceeded, tenant, owned.pixelRate, cost, tenantQuota.MaxPixelRate)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"go_client/config"
+	"testing"
+)
+
+func TestAdmitQuota(t *testing.T) {
+	const cost = 100 * 100 * 10 // 每个会话 100x100@10fps
+
+	tests := []struct {
+		name      string
+		quota     config.Quota
+		tenant    string
+		excludeID string // 非空表示修改已有会话
+		cost      int64
+		exceeded  bool
+	}{
+		{"no quota", config.Quota{}, "a", "", cost, false},
+		{"global sessions reached", config.Quota{MaxSessions: 3}, "c", "", cost, true},
+		{"global sessions ignore terminal", config.Quota{MaxSessions: 4}, "c", "", cost, false},
+		{"global pixel rate exceeded", config.Quota{MaxPixelRate: 3*cost + cost/2}, "c", "", cost, true},
+		{"tenant default sessions reached", config.Quota{Tenant: config.TenantQuota{MaxSessions: 2}}, "a", "", cost, true},
+		{"tenant default counts own sessions only", config.Quota{Tenant: config.TenantQuota{MaxSessions: 2}}, "b", "", cost, false},
+		{"tenant override", config.Quota{Tenant: config.TenantQuota{MaxSessions: 2}, Tenants: map[string]config.TenantQuota{"a": {MaxSessions: 3}}}, "a", "", cost, false},
+		{"tenant pixel rate exceeded", config.Quota{Tenants: map[string]config.TenantQuota{"b": {MaxPixelRate: cost}}}, "b", "", cost, true},
+		{"update does not add a session", config.Quota{MaxSessions: 3}, "a", "a-1", cost, false},
+		{"update replaces own cost", config.Quota{Tenants: map[string]config.TenantQuota{"a": {MaxPixelRate: 2 * cost}}}, "a", "a-1", cost, false},
+		{"update over tenant pixel rate", config.Quota{Tenants: map[string]config.TenantQuota{"a": {MaxPixelRate: 2 * cost}}}, "a", "a-1", 2 * cost, true},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	manager := NewSessionManager(ctx, cancel, zap.NewNop(), &config.Config{}, 60, "", "")
+	for _, session := range []*Session{
+		{id: "a-1", tenant: "a", width: 100, height: 100, framerate: 10},
+		{id: "a-2", tenant: "a", width: 100, height: 100, framerate: 10},
+		{id: "a-3", tenant: "a", width: 100, height: 100, framerate: 10}, // 已停止，不计入占用
+		{id: "b-1", tenant: "b", width: 100, height: 100, framerate: 10},
+	} {
+		manager.sessions.Store(session.id, session)
+	}
+	a3, _ := manager.sessions.Load("a-3")
+	a3.status.setState(SessionStateStopped)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.Engine.Quota = tt.quota
+			manager.cfg.Store(cfg)
+			err := manager.admit(tt.tenant, tt.excludeID, tt.cost)
+			if exceeded := errors.Is(err, ErrQuotaExceeded); exceeded != tt.exceeded {
+				t.Fatalf("admit() = %v, want exceeded %v", err, tt.exceeded)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"github.com/robfig/cron/v3"
+	"slices"
+	"strings"
+	"time"
+	_ "time/tzdata" // 容器内可能没有时区数据
+)
+
+var ErrInvalidSchedule = errors.New("invalid detect schedule")
+
+// scheduleLookahead 每周时间表查找下一次切换的范围
+const scheduleLookahead = 8 * 24 * time.Hour
+
+// cronParser 5 段 cron 表达式或 @daily 等描述符
+var cronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// DetectSchedule 识别时间计划：每周时间表 windows 或 cron 表达式 start/stop 二选一，时间窗内开启识别，其余时间暂停
+type DetectSchedule struct {
+	Timezone string           `json:"timezone,omitempty"` // IANA 时区，如 Asia/Shanghai，默认服务器本地时区
+	Windows  []ScheduleWindow `json:"windows,omitempty"`  // 每周时间表，任一时间窗内开启识别
+	Start    string           `json:"start,omitempty"`    // cron 表达式（5 段或 @daily 等），到点开启识别
+	Stop     string           `json:"stop,omitempty"`     // cron 表达式，到点暂停识别
+}
+
+// ScheduleWindow 每周时间窗，end 早于 start 表示跨夜（如 22:00-06:00）
+type ScheduleWindow struct {
+	Days  []string `json:"days,omitempty"` // 星期 mon tue wed thu fri sat sun，为空表示每天；跨夜时间窗按开始日计算
+	Start string   `json:"start"`          // 开始时间 HH:MM
+	End   string   `json:"end"`            // 结束时间 HH:MM，可为 24:00
+}
+
+// ScheduleOverride 手动覆盖，到期后恢复按计划执行
+type ScheduleOverride struct {
+	Detect bool      `json:"detect"` // 覆盖期间的识别状态
+	Until  time.Time `json:"until"`  // 到期时间
+}
+
+// ScheduleTransition 计划的下一次识别状态切换
+type ScheduleTransition struct {
+	Time   time.Time `json:"time"`
+	Detect bool      `json:"detect"` // 切换后的识别状态
+}
+
+// detectSchedule 已解析的识别时间计划
+type detectSchedule struct {
+	spec     DetectSchedule
+	location *time.Location
+	windows  []weeklyWindow
+	start    cron.Schedule
+	stop     cron.Schedule
+}
+
+type weeklyWindow struct {
+	days       [7]bool
+	start, end int // 当天分钟数
+}
+
+// compile 校验并解析计划，nil 或空计划返回 nil
+func (d *DetectSchedule) compile() (*detectSchedule, error) {
+	if d == nil || (len(d.Windows) == 0 && d.Start == "" && d.Stop == "") {
+		return nil, nil
+	}
+	schedule := &detectSchedule{spec: *d, location: time.Local}
+	if d.Timezone != "" {
+		location, err := time.LoadLocation(d.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("%w: timezone: %w", ErrInvalidSchedule, err)
+		}
+		schedule.location = location
+	}
+
+	if len(d.Windows) > 0 {
+		if d.Start != "" || d.Stop != "" {
+			return nil, fmt.Errorf("%w: windows and cron start/stop are mutually exclusive", ErrInvalidSchedule)
+		}
+		for i, w := range d.Windows {
+			window, err := w.compile()
+			if err != nil {
+				return nil, fmt.Errorf("%w: windows[%d]: %w", ErrInvalidSchedule, i, err)
+			}
+			schedule.windows = append(schedule.windows, window)
+		}
+		return schedule, nil
+	}
+
+	if d.Start == "" || d.Stop == "" {
+		return nil, fmt.Errorf("%w: cron schedule requires both start and stop", ErrInvalidSchedule)
+	}
+	var err error
+	if schedule.start, err = cronParser.Parse(d.Start); err != nil {
+		return nil, fmt.Errorf("%w: start: %w", ErrInvalidSchedule, err)
+	}
+	if schedule.stop, err = cronParser.Parse(d.Stop); err != nil {
+		return nil, fmt.Errorf("%w: stop: %w", ErrInvalidSchedule, err)
+	}
+	return schedule, nil
+}
+
+func (w ScheduleWindow) compile() (weeklyWindow, error) {
+	var window weeklyWindow
+	if len(w.Days) == 0 {
+		window.days = [7]bool{true, true, true, true, true, true, true}
+	}
+	for _, day := range w.Days {
+		weekday, ok := weekdays[strings.ToLower(day)]
+		if !ok {
+			return window, fmt.Errorf("unknown day %q", day)
+		}
+		window.days[weekday] = true
+	}
+	var err error
+	if window.start, err = parseClock(w.Start); err != nil {
+		return window, fmt.Errorf("start: %w", err)
+	}
+	if window.end, err = parseClock(w.End); err != nil {
+		return window, fmt.Errorf("end: %w", err)
+	}
+	if window.start == window.end {
+		return window, errors.New("start and end must differ")
+	}
+	return window, nil
+}
+
+// parseClock 解析 HH:MM 为当天分钟数，允许 24:00
+func parseClock(s string) (int, error) {
+	var hour, minute int
+	if _, err := fmt.Sscanf(s, "%d:%d", &hour, &minute); err != nil || len(s) != 5 {
+		return 0, fmt.Errorf("invalid time %q, want HH:MM", s)
+	}
+	if hour < 0 || minute < 0 || minute > 59 || hour > 24 || (hour == 24 && minute != 0) {
+		return 0, fmt.Errorf("invalid time %q, want HH:MM", s)
+	}
+	return hour*60 + minute, nil
+}
+
+// at 计划在 t 时刻的识别状态
+func (s *detectSchedule) at(t time.Time) bool {
+	t = t.In(s.location)
+	if len(s.windows) == 0 {
+		// 下一次暂停早于下一次开启，说明当前处于开启区间
+		return s.stop.Next(t).Before(s.start.Next(t))
+	}
+
+	minute := t.Hour()*60 + t.Minute()
+	today, yesterday := t.Weekday(), (t.Weekday()+6)%7
+	for _, w := range s.windows {
+		if w.start < w.end {
+			if w.days[today] && minute >= w.start && minute < w.end {
+				return true
+			}
+			continue
+		}
+		// 跨夜
+		if (w.days[today] && minute >= w.start) || (w.days[yesterday] && minute < w.end) {
+			return true
+		}
+	}
+	return false
+}
+
+// next t 之后的下一次识别状态切换，没有切换时返回 false
+func (s *detectSchedule) next(t time.Time) (ScheduleTransition, bool) {
+	t = t.In(s.location)
+	current := s.at(t)
+	if len(s.windows) == 0 {
+		start, stop := s.start.Next(t), s.stop.Next(t)
+		if current {
+			return ScheduleTransition{Time: stop, Detect: false}, !stop.IsZero()
+		}
+		return ScheduleTransition{Time: start, Detect: true}, !start.IsZero()
+	}
+
+	// 收集前一天起 8 天内的时间窗边界，按时间顺序找到第一个使状态变化的边界
+	boundaries := make([]time.Time, 0)
+	year, month, day := t.Date()
+	for offset := -1; offset <= int(scheduleLookahead/(24*time.Hour)); offset++ {
+		date := time.Date(year, month, day+offset, 0, 0, 0, 0, s.location)
+		for _, w := range s.windows {
+			if !w.days[date.Weekday()] {
+				continue
+			}
+			end := clockOn(date, w.end)
+			if w.end < w.start {
+				end = clockOn(date.AddDate(0, 0, 1), w.end)
+			}
+			boundaries = append(boundaries, clockOn(date, w.start), end)
+		}
+	}
+	slices.SortFunc(boundaries, func(a, b time.Time) int { return a.Compare(b) })
+	for _, boundary := range boundaries {
+		if boundary.After(t) && s.at(boundary) != current {
+			return ScheduleTransition{Time: boundary, Detect: !current}, true
+		}
+	}
+	return ScheduleTransition{}, false
+}
+
+func clockOn(date time.Time, minute int) time.Time {
+	year, month, day := date.Date()
+	return time.Date(year, month, day, minute/60, minute%60, 0, 0, date.Location())
+}
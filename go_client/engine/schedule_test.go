@@ -0,0 +1,60 @@
+package engine
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDetectScheduleWindows(t *testing.T) {
+	schedule, err := (&DetectSchedule{
+		Timezone: "Asia/Shanghai",
+		Windows:  []ScheduleWindow{{Days: []string{"mon", "tue", "wed", "thu", "fri"}, Start: "22:00", End: "06:00"}},
+	}).compile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	loc, _ := time.LoadLocation("Asia/Shanghai")
+
+	tests := []struct {
+		name     string
+		at       time.Time
+		detect   bool
+		nextTime time.Time
+	}{
+		// 2024-06-03 周一
+		{"monday noon", time.Date(2024, 6, 3, 12, 0, 0, 0, loc), false, time.Date(2024, 6, 3, 22, 0, 0, 0, loc)},
+		{"monday night", time.Date(2024, 6, 3, 23, 0, 0, 0, loc), true, time.Date(2024, 6, 4, 6, 0, 0, 0, loc)},
+		{"saturday early morning after friday night", time.Date(2024, 6, 8, 5, 59, 0, 0, loc), true, time.Date(2024, 6, 8, 6, 0, 0, 0, loc)},
+		{"saturday night", time.Date(2024, 6, 8, 23, 0, 0, 0, loc), false, time.Date(2024, 6, 10, 22, 0, 0, 0, loc)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := schedule.at(tt.at); got != tt.detect {
+				t.Fatalf("at() = %v, want %v", got, tt.detect)
+			}
+			next, ok := schedule.next(tt.at)
+			if !ok || !next.Time.Equal(tt.nextTime) || next.Detect == tt.detect {
+				t.Fatalf("next() = %+v %v, want %s", next, ok, tt.nextTime)
+			}
+		})
+	}
+}
+
+func TestDetectScheduleCron(t *testing.T) {
+	schedule, err := (&DetectSchedule{Timezone: "UTC", Start: "0 8 * * *", Stop: "0 18 * * *"}).compile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := time.Date(2024, 6, 3, 12, 0, 0, 0, time.UTC)
+	if !schedule.at(at) {
+		t.Fatal("expected detect at noon")
+	}
+	next, ok := schedule.next(at)
+	if !ok || next.Detect || !next.Time.Equal(time.Date(2024, 6, 3, 18, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected next transition %+v", next)
+	}
+
+	if _, err := (&DetectSchedule{Start: "0 8 * * *"}).compile(); err == nil {
+		t.Fatal("expected error when stop is missing")
+	}
+}
@@ -20,7 +20,7 @@ func WrapHandler(fc HandlerFunc) gin.HandlerFunc {
 			result.Any(statusErr.StatusCode).Message(statusErr.Error()).Err(c.Writer)
 			return
 		}
-		
-		result.Any(http.StatusUnauthorized).Message(err.Error()).Err(c.Writer)
+
+		result.Any(http.StatusInternalServerError).Message(err.Error()).Err(c.Writer)
 	}
 }
@@ -2,14 +2,20 @@ package engine
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"go_client/config"
+	"go_client/pkg/tracing"
 	"gocv.io/x/gocv"
 	"image"
 	"image/color"
 	"io"
 	"net"
 	"os/exec"
+	"reflect"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -17,10 +23,32 @@ import (
 )
 
 type SessionDesc struct {
-	ID            string `json:"id"`            // 唯一标识
-	StreamKey     string `json:"streamKey"`     // 用于拼接 RTMP 推流地址
-	PushUrlPublic string `json:"pushUrlPublic"` // 播放展示用
-	DetectStatus  bool   `json:"detectStatus"`  // 识别状态 false 停止 true 识别
+	ID             string                 `json:"id"`                  // 唯一标识
+	Tenant         string                 `json:"tenant,omitempty"`    // 所属租户
+	Name           string                 `json:"name,omitempty"`      // 展示名称
+	Site           string                 `json:"site,omitempty"`      // 站点/位置
+	Labels         map[string]string      `json:"labels,omitempty"`    // 标签
+	Metadata       json.RawMessage        `json:"metadata,omitempty"`  // 自定义元数据 JSON
+	StreamKey      string                 `json:"streamKey"`           // 用于拼接 RTMP 推流地址
+	PushUrlPublic  string                 `json:"pushUrlPublic"`       // 播放展示用
+	DetectStatus   bool                   `json:"detectStatus"`        // 识别状态 false 停止 true 识别
+	Debug          bool                   `json:"debug"`               // 调试模式
+	State          string                 `json:"state"`               // 会话状态 preparing running reconnecting failed stopped
+	Width          int                    `json:"width"`               //  宽
+	Height         int                    `json:"height"`              //  高
+	Framerate      int                    `json:"framerate"`           // 帧率
+	Encoding       config.EncodingProfile `json:"encoding"`            // 推流编码配置
+	ReconnectCount int64                  `json:"reconnectCount"`      // 累计重连次数
+	StallRestarts  int64                  `json:"stallRestarts"`       // 看门狗因卡流累计重启 FFmpeg 次数
+	UptimeSeconds  float64                `json:"uptimeSeconds"`       // 运行时长 s
+	LastError      *SessionError          `json:"lastError,omitempty"` // 最近一次错误
+	Errors         []SessionError         `json:"errors,omitempty"`    // 错误历史
+	Stats          SessionStats           `json:"stats"`               // 流统计
+
+	Schedule         *DetectSchedule     `json:"schedule,omitempty"`         // 识别时间计划
+	ScheduleOverride *ScheduleOverride   `json:"scheduleOverride,omitempty"` // 生效中的手动覆盖
+	NextTransition   *ScheduleTransition `json:"nextTransition,omitempty"`   // 下一次识别状态切换
+	Counting         *LineCounting       `json:"counting,omitempty"`         // 虚拟线计数配置
 }
 
 type DetectionResultCache struct {
@@ -36,28 +64,53 @@ type DetectionResultCache struct {
 
 // Session 流会话
 type Session struct {
-	width         int         //  宽
-	height        int         //  高
-	framerate     int         // 帧率
-	id            string      // 唯一标识
-	streamKey     string      // 用于拼接 RTMP 推流地址
-	rtspURL       string      // 拉流链接
-	detectStatus  atomic.Bool // 识别状态 false 停止 true 识别
-	runningStatus atomic.Bool // 运行状态 false 关闭 true 运行中
+	width         int                     //  宽
+	height        int                     //  高
+	framerate     int                     // 帧率
+	id            string                  // 唯一标识
+	tenant        string                  // 所属租户
+	name          string                  // 展示名称，创建后不可修改
+	site          string                  // 站点/位置，创建后不可修改
+	labels        map[string]string       // 标签，创建后不可修改
+	metadata      json.RawMessage         // 自定义元数据 JSON，创建后不可修改
+	declared      *config.DeclaredSession // 配置文件声明，nil 表示通过 API 创建
+	declaredMu    sync.RWMutex            // 保护 declared，调和更新声明时加写锁
+	streamKey     string                  // 用于拼接 RTMP 推流地址
+	rtspURL       string                  // 拉流链接
+	aiURL         atomic.Value            // 识别请求URL，可热更新
+	detectStatus  atomic.Bool             // 识别状态 false 停止 true 识别
+	runningStatus atomic.Bool             // 运行状态 false 关闭 true 运行中
+	debug         atomic.Bool             // 调试模式
 	handledClose  atomic.Bool
+	retryTimes    int                    // 拉流断开后连续重连次数上限
+	encoding      config.EncodingProfile // 推流编码配置
+	status        sessionStatus          // 状态机、错误历史与流统计
+	scheduleMu    sync.Mutex
+	schedule      sessionSchedule // 识别时间计划与手动覆盖
+	countingMu    sync.Mutex
+	counting      sessionCounting // 虚拟线计数
 	ctx           context.Context
 	cancelFunc    context.CancelFunc
-	logger        *zap.Logger
-
-	closeCh chan<- string
-
-	pullFFmpegCmd *exec.Cmd      // FFmpeg 拉流Cmd
-	pullReader    io.Reader      // 拉流Reader
-	ffmpegStdin   io.WriteCloser //  FFmpeg 推流进程的 stdin 管道
-	pushFFmpegCmd *exec.Cmd      // FFmpeg 推流Cmd
-
+	logger        *zap.Logger // 会话日志，携带 session_id 与 stream_key
+	frameLogger   *zap.Logger // 采样日志，用于逐帧等高频消息
+
+	closeCh       chan<- string
+	eventHandlers []DetectionEventHandler // 识别事件处理函数
+	auditLog      *AuditLog               // 生命周期审计日志
+
+	pipeMu        sync.RWMutex    // 保护 FFmpeg 进程、管道与帧尺寸，重启 FFmpeg 时加写锁
+	pipeGen       atomic.Uint64   // FFmpeg 管道代数，每次重启 FFmpeg 自增
+	pushRTMPURL   string          // 推流地址，重启推流时沿用同一 streamKey
+	pullFFmpegCmd *exec.Cmd       // FFmpeg 拉流Cmd
+	pullReader    io.Reader       // 拉流Reader
+	ffmpegStdin   io.WriteCloser  //  FFmpeg 推流进程的 stdin 管道
+	pushFFmpegCmd *exec.Cmd       // FFmpeg 推流Cmd
+	ffmpegLog     ffmpegLogBuffer // FFmpeg stderr 日志
+
+	frameSeq          atomic.Uint64 // 拉流帧序号，用于关联识别请求
 	resultCache       *DetectionResultCache
-	frameForDetection chan []byte
+	frameForDetection chan detectFrame
+	runDone           chan struct{} // Run 退出后关闭
 }
 
 type SetSessionOption func(s *Session)
@@ -80,6 +133,94 @@ func SetSessionFramerate(framerate int) SetSessionOption {
 	}
 }
 
+func SetSessionRetryTimes(retryTimes int) SetSessionOption {
+	return func(s *Session) {
+		s.retryTimes = retryTimes
+	}
+}
+
+func SetSessionEncoding(encoding config.EncodingProfile) SetSessionOption {
+	return func(s *Session) {
+		s.encoding = encoding
+	}
+}
+
+func SetSessionLabels(labels map[string]string) SetSessionOption {
+	return func(s *Session) {
+		s.labels = labels
+	}
+}
+
+func SetSessionName(name string) SetSessionOption {
+	return func(s *Session) {
+		s.name = name
+	}
+}
+
+func SetSessionSite(site string) SetSessionOption {
+	return func(s *Session) {
+		s.site = site
+	}
+}
+
+func SetSessionMetadata(metadata json.RawMessage) SetSessionOption {
+	return func(s *Session) {
+		s.metadata = metadata
+	}
+}
+
+// SetSessionSchedule 设置识别时间计划，由 SessionManager 创建会话时校验解析
+func SetSessionSchedule(schedule *DetectSchedule) SetSessionOption {
+	return func(s *Session) {
+		s.schedule = sessionSchedule{spec: schedule}
+	}
+}
+
+func SetSessionLineCounting(counting *LineCounting) SetSessionOption {
+	return func(s *Session) {
+		s.counting = sessionCounting{spec: counting}
+	}
+}
+
+func SetSessionDeclared(declared config.DeclaredSession) SetSessionOption {
+	return func(s *Session) {
+		s.declared = &declared
+	}
+}
+
+// Declared 配置文件声明，nil 表示通过 API 创建
+func (s *Session) Declared() *config.DeclaredSession {
+	s.declaredMu.RLock()
+	defer s.declaredMu.RUnlock()
+	return s.declared
+}
+
+func (s *Session) setDeclared(declared *config.DeclaredSession) {
+	s.declaredMu.Lock()
+	defer s.declaredMu.Unlock()
+	s.declared = declared
+}
+
+// 未指定分辨率或帧率时的默认值
+const (
+	defaultWidth     = 1280
+	defaultHeight    = 720
+	defaultFramerate = 25
+)
+
+// resolveVideoDefaults 未指定（不大于 0）的分辨率与帧率使用默认值
+func (s *Session) resolveVideoDefaults() {
+	if s.width <= 0 {
+		s.width = defaultWidth
+	}
+	if s.height <= 0 {
+		s.height = defaultHeight
+	}
+	if s.framerate <= 0 {
+		s.framerate = defaultFramerate
+	}
+}
+
 func SetSessionVideoStreamConfig(with, height, framerate int) SetSessionOption {
 	return func(s *Session) {
 		s.width = with
@@ -100,6 +241,7 @@ func NewSessionWithCtx(id, rtsp string, ctx context.Context, cancelFunc context.
 		closeCh:       closeCh,
 		rtspURL:       rtsp,
 		logger:        logger,
+		frameLogger:   logger,
 	}
 
 	// set option
@@ -127,31 +269,34 @@ func (s *Session) Reset() {
 		s.cancelFunc()
 	}
 
-	// 重置状态
-	s.detectStatus.Store(false)
 	s.runningStatus.Store(false)
-	s.handledClose.Store(false)
 
-	// 停止拉流 FFmpeg 进程
-	if s.pullFFmpegCmd != nil && s.pullFFmpegCmd.Process != nil {
-		_ = s.pullFFmpegCmd.Process.Kill()
-		_ = s.pullFFmpegCmd.Wait()
-	}
-	s.pullFFmpegCmd = nil
-	s.pullReader = nil
+	// 停止拉流 FFmpeg 进程，使阻塞在读帧的 Run 退出
+	s.pipeMu.Lock()
+	s.stopPuller()
+	s.pipeMu.Unlock()
 
-	// 停止推流 FFmpeg 进程
-	if s.pushFFmpegCmd != nil && s.pushFFmpegCmd.Process != nil {
-		_ = s.pushFFmpegCmd.Process.Kill()
-		_ = s.pushFFmpegCmd.Wait()
+	// 等待 Run 退出，避免其延迟清理作用于放回池中的会话
+	if s.runDone != nil {
+		<-s.runDone
 	}
-	s.pushFFmpegCmd = nil
+	s.runDone = nil
 
-	// 关闭 FFmpeg stdin 写入管道
-	if s.ffmpegStdin != nil {
-		_ = s.ffmpegStdin.Close()
-	}
-	s.ffmpegStdin = nil
+	// 停止推流 FFmpeg 进程 并关闭 FFmpeg stdin 写入管道
+	s.pipeMu.Lock()
+	s.stopPusher()
+	s.pushRTMPURL = ""
+	s.pipeMu.Unlock()
+
+	// 重置状态
+	s.detectStatus.Store(false)
+	s.debug.Store(false)
+	s.frameSeq.Store(0)
+	s.handledClose.Store(false)
+	s.retryTimes = 0
+	s.encoding = config.EncodingProfile{}
+	s.status.reset()
+	s.ffmpegLog.reset()
 
 	// 清空上下文和控制函数
 	s.ctx = nil
@@ -159,12 +304,21 @@ func (s *Session) Reset() {
 
 	// 清空基本信息
 	s.id = ""
+	s.tenant = ""
+	s.name = ""
+	s.site = ""
+	s.labels = nil
+	s.metadata = nil
+	s.setDeclared(nil)
+	s.schedule = sessionSchedule{}
+	s.counting = sessionCounting{}
 	s.streamKey = ""
 	s.rtspURL = ""
+	s.aiURL.Store("")
 
 	s.resultCache = &DetectionResultCache{}
 
-	// 不清空 logger 和 closeCh —— 这些是注入的全局组件，不应被置 nil
+	// 不清空 logger、closeCh 和 eventHandlers —— 这些是注入的全局组件，不应被置 nil
 
 }
 
@@ -192,76 +346,326 @@ func (s *Session) GetCtx() context.Context {
 }
 
 func (s *Session) GetDesc(pushUrlPublicPre string) SessionDesc {
+	s.pipeMu.RLock()
+	width, height, framerate, encoding := s.width, s.height, s.framerate, s.encoding
+	s.pipeMu.RUnlock()
+	schedule, override, next := s.scheduleDesc(time.Now())
+
 	return SessionDesc{
-		ID:            s.id,
-		StreamKey:     s.streamKey,
-		PushUrlPublic: pushUrlPublicPre + s.streamKey,
-		DetectStatus:  s.detectStatus.Load(),
+		ID:             s.id,
+		Tenant:         s.tenant,
+		Name:           s.name,
+		Site:           s.site,
+		Labels:         s.labels,
+		Metadata:       s.metadata,
+		StreamKey:      s.streamKey,
+		PushUrlPublic:  pushUrlPublicPre + s.streamKey,
+		DetectStatus:   s.detectStatus.Load(),
+		Debug:          s.debug.Load(),
+		State:          s.status.State().String(),
+		Width:          width,
+		Height:         height,
+		Framerate:      framerate,
+		Encoding:       encoding,
+		ReconnectCount: s.status.reconnectCount.Load(),
+		StallRestarts:  s.status.stallRestarts.Load(),
+		UptimeSeconds:  s.status.Uptime().Seconds(),
+		LastError:      s.status.LastError(),
+		Errors:         s.status.Errors(),
+		Stats:          s.status.Stats(),
+
+		Schedule:         schedule,
+		ScheduleOverride: override,
+		NextTransition:   next,
+		Counting:         s.lineCountingDesc(),
+	}
+}
+
+func (s *Session) State() SessionState {
+	return s.status.State()
+}
+
+func (s *Session) StoppedAt() time.Time {
+	return s.status.StoppedAt()
+}
+
+// fail 记录错误并切换为失败状态
+func (s *Session) fail(stage string, err error) {
+	s.status.recordError(stage, err)
+	if s.status.setState(SessionStateFailed) {
+		s.audit(AuditFailed, fmt.Sprintf("%s: %v", stage, err))
 	}
 }
 
-func (s *Session) PrepareStream(pushRTMPURL string) (err error) {
+func (s *Session) GetAIURL() string {
+	aiURL, _ := s.aiURL.Load().(string)
+	return aiURL
+}
+
+func (s *Session) PrepareStream(ctx context.Context, pushRTMPURL string) (err error) {
+	_, span := tracing.Tracer().Start(ctx, "Session.PrepareStream", trace.WithAttributes(
+		attribute.String("session.id", s.id),
+		attribute.Int("width", s.width), attribute.Int("height", s.height), attribute.Int("fps", s.framerate),
+	))
+	defer func() { tracing.End(span, err) }()
+
 	s.runningStatus.Store(true)
 	s.detectStatus.Store(false) // 预准备时不识别 需要手动开启识别
-	s.logger.Info(fmt.Sprintf("📽️ Session starting: url=%s, res=%dx%d, fps=%d", s.rtspURL, s.width, s.height, s.framerate))
+	s.logger.Info("📽️ Session starting", zap.String("url", s.rtspURL), zap.Int("width", s.width), zap.Int("height", s.height), zap.Int("fps", s.framerate))
 
-	cleanup := func() {
-		if s.pullFFmpegCmd != nil {
-			_ = s.pullFFmpegCmd.Process.Kill()
-			_ = s.pullFFmpegCmd.Wait()
-		}
-		if s.ffmpegStdin != nil {
-			_ = s.ffmpegStdin.Close()
-		}
-		if s.pushFFmpegCmd != nil {
-			_ = s.pushFFmpegCmd.Process.Kill()
-			_ = s.pushFFmpegCmd.Wait()
-		}
-	}
+	s.pipeMu.Lock()
+	defer s.pipeMu.Unlock()
 
 	defer func() {
 		if err != nil {
-			cleanup() // 仅在出错时回收
+			// 仅在出错时回收
+			s.stopPuller()
+			s.stopPusher()
+			s.fail("prepare", err)
+			return
 		}
+		s.status.touchFrames()
+		s.status.setState(SessionStateRunning)
 	}()
 
+	s.pushRTMPURL = pushRTMPURL
+
 	// 启动拉流 FFmpeg（RTSP → stdout）
-	pullCmd, stdout, err := startFFmpegReader(s.rtspURL, s.width, s.height, s.framerate)
+	if err = s.startPuller(); err != nil {
+		return err
+	}
+
+	// 启动推流 FFmpeg（stdin → RTMP）
+	if err = s.startPusher(); err != nil {
+		return err
+	}
+
+	s.logger.Info("拉流与推流 FFmpeg 初始化完成")
+	return nil
+}
+
+// startPuller 启动拉流 FFmpeg，调用方需持有 pipeMu 写锁
+func (s *Session) startPuller() error {
+	isDebug := s.debug.Load()
+	pullCmd, stdout, err := startFFmpegReader(s.rtspURL, s.width, s.height, s.framerate, isDebug,
+		s.newFFmpegLogWriter(FFmpegLogSourcePull, isDebug))
 	if err != nil {
 		return fmt.Errorf("FFmpeg 拉流失败: %w", err)
 	}
 	s.pullFFmpegCmd = pullCmd
 	s.pullReader = stdout
+	return nil
+}
 
-	// 启动推流 FFmpeg（stdin → RTMP）
-	pushCmd, pushIO, err := startFFmpegPusher(s.width, s.height, float64(s.framerate), false, pushRTMPURL, s.logger)
+// startPusher 启动推流 FFmpeg，调用方需持有 pipeMu 写锁
+func (s *Session) startPusher() error {
+	isDebug := s.debug.Load()
+	pushCmd, pushIO, err := startFFmpegPusher(s.width, s.height, float64(s.framerate), isDebug, s.pushRTMPURL, s.encoding,
+		s.newFFmpegLogWriter(FFmpegLogSourcePush, isDebug))
 	if err != nil {
 		return fmt.Errorf("FFmpeg 推流失败: %w", err)
 	}
 	s.ffmpegStdin = pushIO
 	s.pushFFmpegCmd = pushCmd
+	return nil
+}
+
+// Shutdown 优雅停止会话：停止拉流，关闭推流 stdin 使 FFmpeg 完成剩余写入，ctx 结束后强制结束推流
+func (s *Session) Shutdown(ctx context.Context) error {
+	if s.status.setState(SessionStateStopped) {
+		s.audit(AuditStopped, "engine shutdown")
+	}
+	s.runningStatus.Store(false)
+	if s.cancelFunc != nil {
+		s.cancelFunc()
+	}
+
+	s.pipeMu.Lock()
+	s.stopPuller()
+	s.pipeMu.Unlock()
+
+	if s.runDone == nil {
+		return nil
+	}
+
+	select {
+	case <-s.runDone:
+		return nil
+	case <-ctx.Done():
+		s.pipeMu.Lock()
+		if s.pushFFmpegCmd != nil && s.pushFFmpegCmd.Process != nil {
+			_ = s.pushFFmpegCmd.Process.Kill()
+		}
+		s.pipeMu.Unlock()
+		<-s.runDone
+		return ctx.Err()
+	}
+}
+
+// newFFmpegLogWriter 创建 FFmpeg stderr 日志写入器：写入环形缓冲，匹配已知错误记入错误历史，isDebug 时同时输出到日志
+func (s *Session) newFFmpegLogWriter(source string, isDebug bool) *ffmpegLogWriter {
+	return &ffmpegLogWriter{
+		onLine: func(line string) {
+			s.ffmpegLog.add(FFmpegLogLine{Time: time.Now(), Source: source, Line: line})
+			if code := matchFFmpegError(line); code != "" {
+				s.status.recordErrorCode(source, code, line)
+			}
+			if isDebug {
+				s.logger.Debug("FFmpeg output", zap.String("source", source), zap.String("line", line))
+			}
+		},
+	}
+}
+
+// FFmpegLogs 最近的 FFmpeg stderr 日志
+func (s *Session) FFmpegLogs(source string, limit int) []FFmpegLogLine {
+	return s.ffmpegLog.Lines(source, limit)
+}
+
+// stopPuller 停止拉流 FFmpeg，调用方需持有 pipeMu 写锁
+func (s *Session) stopPuller() {
+	if s.pullFFmpegCmd != nil && s.pullFFmpegCmd.Process != nil {
+		_ = s.pullFFmpegCmd.Process.Kill()
+		_ = s.pullFFmpegCmd.Wait()
+	}
+	s.pullFFmpegCmd = nil
+	s.pullReader = nil
+}
+
+// stopPusher 关闭推流 stdin 并停止推流 FFmpeg，调用方需持有 pipeMu 写锁
+func (s *Session) stopPusher() {
+	if s.ffmpegStdin != nil {
+		_ = s.ffmpegStdin.Close()
+	}
+	s.ffmpegStdin = nil
+	if s.pushFFmpegCmd != nil && s.pushFFmpegCmd.Process != nil {
+		_ = s.pushFFmpegCmd.Process.Kill()
+		_ = s.pushFFmpegCmd.Wait()
+	}
+	s.pushFFmpegCmd = nil
+}
+
+// SessionUpdate 会话运行时更新内容，nil 表示不修改
+type SessionUpdate struct {
+	RtspURL      *string // 拉流链接，修改后仅重启拉流 FFmpeg
+	Width        *int    // 宽，修改后重启拉流与推流 FFmpeg
+	Height       *int    // 高，修改后重启拉流与推流 FFmpeg
+	Framerate    *int    // 帧率，修改后重启拉流与推流 FFmpeg
+	AIURL        *string // 识别请求URL，热更新
+	DetectStatus *bool   // 识别状态，热更新，设置识别时间计划时视为到下一次切换为止的手动覆盖
+	Debug        *bool   // 调试模式：会话日志输出 debug 等级，FFmpeg 输出详细日志并写入会话日志，修改后重启拉流与推流 FFmpeg
+
+	EncodingProfile *string                 // 命名编码配置，由 SessionManager 合并到 Encoding
+	Encoding        *config.EncodingProfile // 推流编码配置，SessionManager 合并后为完整配置，修改后仅重启推流 FFmpeg
+
+	Schedule *DetectSchedule // 识别时间计划，由 SessionManager 设置，空计划表示取消
+	Counting *LineCounting   // 虚拟线计数，由 SessionManager 设置，修改后计数清零，没有线表示关闭
+}
+
+// Update 运行时更新会话配置：可热更新字段直接生效，其余字段仅重启所需的 FFmpeg 进程，streamKey 保持不变
+func (s *Session) Update(update SessionUpdate) (err error) {
+	if !s.runningStatus.Load() {
+		return fmt.Errorf("%w: %s", ErrSessionNotRunning, s.id)
+	}
+
+	if update.AIURL != nil {
+		s.aiURL.Store(*update.AIURL)
+	}
+
+	s.pipeMu.Lock()
+	defer s.pipeMu.Unlock()
+
+	restartPull, restartPush := false, false
+	if update.RtspURL != nil && *update.RtspURL != s.rtspURL {
+		s.rtspURL = *update.RtspURL
+		restartPull = true
+	}
+	if update.Width != nil && *update.Width != s.width {
+		s.width = *update.Width
+		restartPull, restartPush = true, true
+	}
+	if update.Height != nil && *update.Height != s.height {
+		s.height = *update.Height
+		restartPull, restartPush = true, true
+	}
+	if update.Framerate != nil && *update.Framerate != s.framerate {
+		s.framerate = *update.Framerate
+		restartPull, restartPush = true, true
+	}
+	if update.Debug != nil && s.debug.Swap(*update.Debug) != *update.Debug {
+		restartPull, restartPush = true, true
+	}
+	if update.Encoding != nil && !reflect.DeepEqual(*update.Encoding, s.encoding) {
+		s.encoding = *update.Encoding
+		restartPush = true
+	}
+
+	if restartPull || restartPush {
+		// 先递增管道代数，Run 中因旧管道关闭产生的读写错误不再视为流断开
+		s.pipeGen.Add(1)
+		s.logger.Info("🔁 Session reconfiguring", zap.String("url", s.rtspURL), zap.Int("width", s.width), zap.Int("height", s.height),
+			zap.Int("fps", s.framerate), zap.Bool("restartPull", restartPull), zap.Bool("restartPush", restartPush))
+
+		defer func() {
+			if err != nil {
+				// 重启失败 会话无法继续运行
+				s.fail("update", err)
+				s.runningStatus.Store(false)
+				s.cancelFunc()
+			}
+		}()
+
+		if restartPull {
+			s.stopPuller()
+			if err = s.startPuller(); err != nil {
+				return err
+			}
+		}
+		if restartPush {
+			s.stopPusher()
+			if err = s.startPusher(); err != nil {
+				return err
+			}
+		}
+		s.status.touchFrames()
+	}
+
+	if update.DetectStatus != nil {
+		s.detectStatus.Store(*update.DetectStatus)
+	}
 
-	s.logger.Info("拉流与推流 FFmpeg 初始化完成")
 	return nil
 }
 
-func (s *Session) Run(aiDetectAIURL string, uvicornSocket bool, socketPath string) {
+func (s *Session) Run(uvicornSocket bool, socketPath string) {
+	defer close(s.runDone)
 	defer func() {
 		if r := recover(); r != nil {
 			s.logger.Error("❌ Panic recovered in Run", zap.Any("error", r))
+			s.fail("run", fmt.Errorf("panic: %v", r))
+		}
+
+		// 未失败的退出均视为主动停止
+		if s.status.setState(SessionStateStopped) {
+			s.audit(AuditStopped, "")
 		}
 
 		// 关闭资源
 		s.runningStatus.Store(false)
-		if s.pullFFmpegCmd != nil && s.pullFFmpegCmd.Process != nil {
-			_ = s.pullFFmpegCmd.Process.Kill()
-			_ = s.pullFFmpegCmd.Wait()
-		}
+		s.pipeMu.Lock()
+		s.stopPuller()
 		if s.ffmpegStdin != nil {
 			_ = s.ffmpegStdin.Close()
+			s.ffmpegStdin = nil
 		}
-		if s.pushFFmpegCmd != nil && s.pushFFmpegCmd.Process != nil {
-			_ = s.pushFFmpegCmd.Wait()
+		pushCmd := s.pushFFmpegCmd
+		s.pipeMu.Unlock()
+
+		// 关闭 stdin 后等待推流 FFmpeg 写完剩余数据并退出，不持有锁以便超时强制结束
+		if pushCmd != nil && pushCmd.Process != nil {
+			if err := waitFFmpeg(pushCmd, pushFlushTimeout); err != nil {
+				s.logger.Warn("推流 FFmpeg 退出异常", zap.Error(err))
+			}
 		}
 
 		s.SendIDToCloseCh()
@@ -269,14 +673,25 @@ func (s *Session) Run(aiDetectAIURL string, uvicornSocket bool, socketPath strin
 	}()
 
 	s.resultCache = &DetectionResultCache{}
-	imgBuf := make([]byte, s.width*s.height*3)
+	var imgBuf []byte
+	reconnectAttempts := 0 // 连续重连次数，读到帧后清零
 	img := gocv.NewMat()
 	defer img.Close()
 
-	// 异步识别 goroutine
-	go s.asyncDetectLoop(uvicornSocket, socketPath, aiDetectAIURL)
+	// 异步识别 goroutine，Run 退出前停止并等待其退出，保证 runDone 关闭后会话不再被访问
+	detectCtx, stopDetect := context.WithCancel(s.ctx)
+	detectDone := make(chan struct{})
+	go func() {
+		defer close(detectDone)
+		s.asyncDetectLoop(detectCtx, uvicornSocket, socketPath)
+	}()
+	defer func() {
+		stopDetect()
+		<-detectDone
+	}()
 
 	lastDetect := time.Now()
+	wasDetecting := false
 	detectInterval := time.Second / 5 // 每秒识别 5 帧
 
 	for {
@@ -289,21 +704,54 @@ func (s *Session) Run(aiDetectAIURL string, uvicornSocket bool, socketPath strin
 				return
 			}
 
-			_, err := io.ReadFull(s.pullReader, imgBuf)
+			// 读取当前管道，Update 可能已重启 FFmpeg 并修改帧尺寸
+			gen := s.pipeGen.Load()
+			s.pipeMu.RLock()
+			pullReader, ffmpegStdin, width, height := s.pullReader, s.ffmpegStdin, s.width, s.height
+			s.pipeMu.RUnlock()
+			if pullReader == nil || ffmpegStdin == nil {
+				return
+			}
+			if len(imgBuf) != width*height*3 {
+				imgBuf = make([]byte, width*height*3)
+			}
+
+			_, err := io.ReadFull(pullReader, imgBuf)
 			if err != nil {
-				if err == io.EOF {
-					s.logger.Error("检测到 EOF，流断开，终止", zap.String("id", s.id))
+				if s.ctx.Err() != nil {
+					// 会话关闭时拉流 FFmpeg 被结束
+					return
+				}
+				if gen != s.pipeGen.Load() {
+					// FFmpeg 已被重启，使用新管道继续
+					continue
+				}
+				if err == io.EOF || err == io.ErrUnexpectedEOF {
+					s.logger.Error("检测到 EOF，流断开")
+					if reconnectAttempts < s.retryTimes {
+						reconnectAttempts++
+						if s.reconnectPuller(reconnectAttempts, err) {
+							continue
+						}
+					}
+					s.fail("pull", fmt.Errorf("拉流断开，重连 %d 次失败: %w", s.retryTimes, err))
 					s.cancelFunc()
 					return
 				}
 
 				// 非EOF错误继续
-				s.logger.Info("读取帧错误，跳过当前帧", zap.String("id", s.id), zap.Error(err))
+				s.frameLogger.Info("读取帧错误，跳过当前帧", zap.Error(err))
 				continue
 
 			}
+			s.status.markFrameRead()
+			frameID := s.frameSeq.Add(1)
+			if s.status.State() == SessionStateReconnecting && s.status.setState(SessionStateRunning) {
+				reconnectAttempts = 0
+				s.logger.Info("✅ 拉流重连成功")
+			}
 
-			if imgTmp, err := gocv.NewMatFromBytes(s.height, s.width, gocv.MatTypeCV8UC3, imgBuf); err == nil && !imgTmp.Empty() {
+			if imgTmp, err := gocv.NewMatFromBytes(height, width, gocv.MatTypeCV8UC3, imgBuf); err == nil && !imgTmp.Empty() {
 				img.Close()
 				img = imgTmp
 			} else {
@@ -324,9 +772,17 @@ func (s *Session) Run(aiDetectAIURL string, uvicornSocket bool, socketPath strin
 				_ = gocv.PutText(&img, r.Label, image.Pt(r.X1, r.Y1-10),
 					gocv.FontHersheyPlain, 1.2, color.RGBA{255, 0, 0, 0}, 2)
 			}
+			s.drawLineCounts(&img)
+
+			// 识别暂停后结束虚拟线目标跟踪，恢复识别时不与暂停前的位置关联
+			detecting := s.detectStatus.Load()
+			if wasDetecting && !detecting {
+				s.clearLineTracks()
+			}
+			wasDetecting = detecting
 
 			// 控制识别频率（基于时间）
-			if s.detectStatus.Load() && time.Since(lastDetect) >= detectInterval {
+			if detecting && time.Since(lastDetect) >= detectInterval {
 				lastDetect = time.Now()
 				func() {
 					s.resultCache.Lock()
@@ -336,26 +792,59 @@ func (s *Session) Run(aiDetectAIURL string, uvicornSocket bool, socketPath strin
 
 				imgBytes, err := gocv.IMEncode(gocv.JPEGFileExt, img) // ✅ 正确，JPEG 格式
 				if err != nil {
-					s.logger.Error("图像编码失败", zap.Error(err))
+					s.frameLogger.Error("图像编码失败", zap.Error(err))
 					continue // 跳过这一帧
 				}
 				select {
-				case s.frameForDetection <- imgBytes.GetBytes():
+				case s.frameForDetection <- detectFrame{id: frameID, data: imgBytes.GetBytes()}:
 				default:
-					s.logger.Info("识别队列已满，跳过当前帧")
+					s.frameLogger.Info("识别队列已满，跳过当前帧")
 				}
 			}
 
 			// 推送给 FFmpeg 推流进程
-			if _, err := s.ffmpegStdin.Write(img.ToBytes()); err != nil {
-				s.logger.Error(fmt.Sprintf("[-] sessionID:%s 写入推流失败", s.id), zap.Error(err))
+			if _, err := ffmpegStdin.Write(img.ToBytes()); err != nil {
+				if gen != s.pipeGen.Load() {
+					continue
+				}
+				s.logger.Error("[-] 写入推流失败", zap.Error(err))
+				s.fail("push", err)
 				s.cancelFunc()
 				return
 			}
+			s.status.markFramePushed()
 		}
 	}
 }
 
+// reconnectPuller 拉流断开后等待退避时间并重启拉流 FFmpeg，返回 false 表示会话已关闭或重启失败
+func (s *Session) reconnectPuller(attempt int, cause error) bool {
+	s.status.recordError("pull", cause)
+	s.status.setState(SessionStateReconnecting)
+	s.status.reconnectCount.Add(1)
+	s.audit(AuditReconnecting, cause.Error())
+
+	backoff := time.Duration(attempt) * time.Second
+	s.logger.Warn("🔌 拉流重连", zap.Int("attempt", attempt), zap.Int("retryTimes", s.retryTimes), zap.Duration("backoff", backoff))
+
+	select {
+	case <-s.ctx.Done():
+		return false
+	case <-time.After(backoff):
+	}
+
+	s.pipeMu.Lock()
+	defer s.pipeMu.Unlock()
+	s.pipeGen.Add(1)
+	s.stopPuller()
+	if err := s.startPuller(); err != nil {
+		s.status.recordError("pull", err)
+		return false
+	}
+	s.status.touchFrames()
+	return true
+}
+
 func isRetryableError(err error) bool {
 	if err == io.ErrUnexpectedEOF {
 		return true
@@ -375,27 +864,42 @@ func isRetryableError(err error) bool {
 	return false
 }
 
-func (s *Session) asyncDetectLoop(uvicornSocket bool, socketPath string, aiDetectAIURL string) {
+func (s *Session) asyncDetectLoop(ctx context.Context, uvicornSocket bool, socketPath string) {
 	for {
 		select {
-		case <-s.ctx.Done():
+		case <-ctx.Done():
 			return
-		case frameBytes := <-s.frameForDetection:
-			if frameBytes == nil {
+		case frame := <-s.frameForDetection:
+			if frame.data == nil {
 				continue
 			}
 			var results []DetectionResult
 			var err error
+			aiDetectAIURL := s.GetAIURL()
+			ctx, span := tracing.Tracer().Start(ctx, "Session.Detect", trace.WithAttributes(
+				attribute.String("session.id", s.id),
+				attribute.Int64("frame.id", int64(frame.id)),
+				attribute.String("ai.url", aiDetectAIURL),
+			))
+			start := time.Now()
 			if uvicornSocket {
-				results, err = detectObjectsUvicronSocket(frameBytes, socketPath, aiDetectAIURL)
+				results, err = detectObjectsUvicronSocket(ctx, frame.data, socketPath, aiDetectAIURL)
 			} else {
-				results, err = detectObjects(frameBytes, aiDetectAIURL)
+				results, err = detectObjects(ctx, frame.data, aiDetectAIURL)
 			}
+			latency := time.Since(start)
+			span.SetAttributes(attribute.Int64("detect.latency_ms", latency.Milliseconds()), attribute.Int("detect.results", len(results)))
+			tracing.End(span, err)
 
 			if err != nil {
-				s.logger.Error("识别失败", zap.Error(err))
+				s.frameLogger.Error("识别失败", zap.Error(err))
+				s.status.recordError("detect", err)
 				continue
 			}
+			s.status.markAILatency(latency)
+			s.status.detectMeter.Mark()
+			s.observeLines(results)
+			s.emitDetection(results)
 
 			func() {
 				s.resultCache.Lock()
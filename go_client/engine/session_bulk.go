@@ -0,0 +1,236 @@
+package engine
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go_client/pkg/auth"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const bulkConcurrency = 8 // 批量操作并发数，创建时每个会话启动两个 FFmpeg 进程，删除时需等待 FFmpeg 退出
+
+// BulkItemResult 批量操作单项结果
+type BulkItemResult struct {
+	ID      string       `json:"id"`
+	OK      bool         `json:"ok"`
+	Error   string       `json:"error,omitempty"`
+	Session *SessionDesc `json:"session,omitempty"` // 批量创建成功时返回
+}
+
+// BulkResult 批量操作结果，允许部分失败
+type BulkResult struct {
+	Total     int              `json:"total"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Items     []BulkItemResult `json:"items"`
+}
+
+func newBulkResult(items []BulkItemResult) BulkResult {
+	res := BulkResult{Total: len(items), Items: items}
+	for i := range items {
+		if items[i].OK {
+			res.Succeeded++
+		} else {
+			res.Failed++
+		}
+	}
+	return res
+}
+
+// BulkCreateSessions 批量创建会话，单项校验或创建失败不影响其他项，结果与请求顺序一致
+func (s *SessionManager) BulkCreateSessions(ctx context.Context, reqs []CreateSessionReq) BulkResult {
+	items := make([]BulkItemResult, len(reqs))
+	runBulk(len(reqs), func(i int) {
+		req := reqs[i]
+		items[i].ID = req.ID
+		desc, err := s.createFromReq(ctx, req)
+		if err != nil {
+			items[i].Error = err.Error()
+			return
+		}
+		items[i].OK, items[i].Session = true, &desc
+	})
+	return newBulkResult(items)
+}
+
+// runBulk 以 bulkConcurrency 为上限并发执行 fn(0..n-1)，全部完成后返回
+func runBulk(n int, fn func(i int)) {
+	sem := make(chan struct{}, bulkConcurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func (s *SessionManager) createFromReq(ctx context.Context, req CreateSessionReq) (SessionDesc, error) {
+	if err := Validate(req); err != nil {
+		return SessionDesc{}, err
+	}
+	encoding, err := s.ResolveEncodingProfile(req.EncodingProfile, req.Encoding)
+	if err != nil {
+		return SessionDesc{}, err
+	}
+	return s.CreateSession(ctx, req.ID, req.RtspURL, s.Config().Engine.DetectAIURL, req.Options(encoding)...)
+}
+
+// SelectSessionIDs 按 ID 列表与标签选择调用方可见的会话，结果取并集；ID 列表中的会话不存在时同样返回，由批量操作报告失败
+func (s *SessionManager) SelectSessionIDs(ctx context.Context, ids, labels []string) []string {
+	selected := make([]string, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			selected = append(selected, id)
+		}
+	}
+	if len(labels) == 0 {
+		return selected
+	}
+
+	principal := auth.FromContext(ctx)
+	filter := SessionFilter{Labels: labels}
+	matched := make([]string, 0)
+	s.sessions.Range(func(key string, _session *Session) bool {
+		if !seen[key] && principal.CanAccess(_session.tenant) && filter.match(_session) {
+			matched = append(matched, key)
+		}
+		return true
+	})
+	sort.Strings(matched)
+	return append(selected, matched...)
+}
+
+// BulkApply 对选中的会话并发执行操作，结果与 ID 顺序一致
+func (s *SessionManager) BulkApply(ctx context.Context, ids []string, action func(id string, _session *Session) error) BulkResult {
+	items := make([]BulkItemResult, len(ids))
+	runBulk(len(ids), func(i int) {
+		id := ids[i]
+		items[i].ID = id
+		_session, exists := s.loadSession(ctx, id)
+		if !exists {
+			items[i].Error = fmt.Errorf("%w: %s", ErrSessionNotExists, id).Error()
+			return
+		}
+		if err := action(id, _session); err != nil {
+			items[i].Error = err.Error()
+			return
+		}
+		items[i].OK = true
+	})
+	return newBulkResult(items)
+}
+
+// BulkSetDetectStatus 批量开启或暂停识别
+func (s *SessionManager) BulkSetDetectStatus(ctx context.Context, ids []string, detect bool) BulkResult {
+	return s.BulkApply(ctx, ids, func(_ string, _session *Session) error {
+		s.setDetectStatus(ctx, _session, detect)
+		return nil
+	})
+}
+
+// BulkRemoveSessions 批量删除会话
+func (s *SessionManager) BulkRemoveSessions(ctx context.Context, ids []string) BulkResult {
+	return s.BulkApply(ctx, ids, func(id string, _session *Session) error {
+		return s.removeUndeclaredSession(ctx, id, _session)
+	})
+}
+
+// ParseSessionInventoryCSV 解析 CSV 摄像头清单，首行为表头，列名与创建会话请求 JSON 字段一致：
+// id rtspURL width height framerate retryTimes name site labels encodingProfile metadata，
+// labels 格式为 key=value;key=value，metadata 为 JSON
+func ParseSessionInventoryCSV(r io.Reader) ([]CreateSessionReq, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read csv header: %w", err)
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(header[i])
+		if !slices.Contains(inventoryColumns, header[i]) {
+			return nil, fmt.Errorf("unknown csv column: %s", header[i])
+		}
+	}
+
+	reqs := make([]CreateSessionReq, 0)
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read csv line %d: %w", line, err)
+		}
+		var req CreateSessionReq
+		for i, value := range record {
+			if value = strings.TrimSpace(value); value == "" {
+				continue
+			}
+			if err := setInventoryField(&req, header[i], value); err != nil {
+				return nil, fmt.Errorf("csv line %d column %s: %w", line, header[i], err)
+			}
+		}
+		reqs = append(reqs, req)
+	}
+	return reqs, nil
+}
+
+var inventoryColumns = []string{"id", "rtspURL", "width", "height", "framerate", "retryTimes", "name", "site", "labels", "encodingProfile", "metadata"}
+
+// setInventoryField 按列名设置创建会话请求字段
+func setInventoryField(req *CreateSessionReq, column, value string) (err error) {
+	switch column {
+	case "id":
+		req.ID = value
+	case "rtspURL":
+		req.RtspURL = value
+	case "width":
+		req.Width, err = strconv.Atoi(value)
+	case "height":
+		req.Height, err = strconv.Atoi(value)
+	case "framerate":
+		req.Framerate, err = strconv.Atoi(value)
+	case "retryTimes":
+		req.RetryTimes, err = strconv.Atoi(value)
+	case "name":
+		req.Name = value
+	case "site":
+		req.Site = value
+	case "encodingProfile":
+		req.EncodingProfile = value
+	case "labels":
+		req.Labels = make(map[string]string)
+		for _, pair := range strings.Split(value, ";") {
+			key, v, _ := strings.Cut(strings.TrimSpace(pair), "=")
+			if key == "" {
+				return fmt.Errorf("invalid label: %q", pair)
+			}
+			req.Labels[key] = v
+		}
+	case "metadata":
+		if !json.Valid([]byte(value)) {
+			return errors.New("metadata is not valid json")
+		}
+		req.Metadata = json.RawMessage(value)
+	default:
+		return fmt.Errorf("unknown csv column: %s", column)
+	}
+	return err
+}
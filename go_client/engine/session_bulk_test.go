@@ -0,0 +1,78 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"go_client/config"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestBulkResultAggregation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	manager := NewSessionManager(ctx, cancel, zap.NewNop(), &config.Config{}, 60, "", "")
+	for _, id := range []string{"cam-1", "cam-2", "cam-3"} {
+		manager.sessions.Store(id, &Session{id: id})
+	}
+
+	var calls atomic.Int32
+	res := manager.BulkApply(ctx, []string{"cam-1", "cam-9", "cam-2", "cam-3"}, func(id string, _ *Session) error {
+		calls.Add(1)
+		if id == "cam-2" {
+			return errors.New("boom")
+		}
+		return nil
+	})
+	if calls.Load() != 3 {
+		t.Fatalf("want action called for 3 existing sessions, got %d", calls.Load())
+	}
+	if res.Total != 4 || res.Succeeded != 2 || res.Failed != 2 {
+		t.Fatalf("unexpected counts: %+v", res)
+	}
+	for i, want := range []struct {
+		id  string
+		ok  bool
+		err string
+	}{{"cam-1", true, ""}, {"cam-9", false, "cam-9"}, {"cam-2", false, "boom"}, {"cam-3", true, ""}} {
+		item := res.Items[i]
+		if item.ID != want.id || item.OK != want.ok || !strings.Contains(item.Error, want.err) {
+			t.Fatalf("item %d: got %+v, want %+v", i, item, want)
+		}
+	}
+
+	// 校验失败的项单独报告，不影响结果顺序
+	res = manager.BulkCreateSessions(ctx, []CreateSessionReq{{ID: "cam-4"}, {RtspURL: "rtsp://10.0.0.5/live"}})
+	if res.Total != 2 || res.Failed != 2 || res.Items[0].ID != "cam-4" || res.Items[0].Error == "" || res.Items[1].Error == "" {
+		t.Fatalf("unexpected create result: %+v", res)
+	}
+}
+
+func TestParseSessionInventoryCSV(t *testing.T) {
+	inventory := `id,rtspURL,width,height,framerate,retryTimes,site,labels
+cam-1,rtsp://10.0.0.1/live,1280,720,25,3,gate,zone=east;floor=1
+cam-2,rtsp://10.0.0.2/live,640,480,15,3,,
+`
+	reqs, err := ParseSessionInventoryCSV(strings.NewReader(inventory))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reqs) != 2 {
+		t.Fatalf("want 2 sessions, got %d", len(reqs))
+	}
+	if reqs[0].ID != "cam-1" || reqs[0].Width != 1280 || reqs[0].Site != "gate" || reqs[0].Labels["floor"] != "1" {
+		t.Fatalf("got %+v", reqs[0])
+	}
+	if reqs[1].Labels != nil || reqs[1].Framerate != 15 {
+		t.Fatalf("got %+v", reqs[1])
+	}
+
+	if _, err := ParseSessionInventoryCSV(strings.NewReader("id,unknown\ncam-1,x\n")); err == nil {
+		t.Fatal("want error for unknown column")
+	}
+	if _, err := ParseSessionInventoryCSV(strings.NewReader("id,width\ncam-1,abc\n")); err == nil {
+		t.Fatal("want error for invalid width")
+	}
+}
@@ -0,0 +1,206 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"go_client/pkg/auth"
+	"gocv.io/x/gocv"
+	"image"
+	"image/color"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+// sessionCounting 会话虚拟线计数配置与计数
+type sessionCounting struct {
+	spec    *LineCounting // 原始配置，nil 表示未开启
+	counter *lineCounter
+
+	overlay        []string // 画面叠加文字，计数变化时重建
+	overlayVersion uint64   // overlay 对应的 counter.version
+}
+
+// compileLineCounting 按会话当前分辨率校验并解析虚拟线计数配置
+func (s *Session) compileLineCounting(spec *LineCounting) (*lineCounter, error) {
+	s.pipeMu.RLock()
+	width, height := s.width, s.height
+	s.pipeMu.RUnlock()
+	if err := spec.checkBounds(width, height); err != nil {
+		return nil, err
+	}
+	return spec.compile(time.Now())
+}
+
+// setLineCounting 设置虚拟线计数并清零计数，spec 为 nil 或没有线表示关闭
+func (s *Session) setLineCounting(spec *LineCounting) error {
+	counter, err := s.compileLineCounting(spec)
+	if err != nil {
+		return err
+	}
+	s.applyLineCounting(counter)
+	return nil
+}
+
+// applyLineCounting 使用已校验的计数配置替换当前配置，counter 为 nil 表示关闭
+func (s *Session) applyLineCounting(counter *lineCounter) {
+	s.countingMu.Lock()
+	defer s.countingMu.Unlock()
+	s.counting = sessionCounting{counter: counter}
+	if counter != nil {
+		s.counting.spec = &counter.spec
+	}
+}
+
+// observeLines 用识别结果更新虚拟线计数
+func (s *Session) observeLines(results []DetectionResult) {
+	s.countingMu.Lock()
+	defer s.countingMu.Unlock()
+	if s.counting.counter != nil {
+		s.counting.counter.observe(results, time.Now())
+	}
+}
+
+// clearLineTracks 识别暂停或画面尺寸变化时结束目标跟踪，避免与之前的位置关联产生误计数
+func (s *Session) clearLineTracks() {
+	s.countingMu.Lock()
+	defer s.countingMu.Unlock()
+	if s.counting.counter != nil {
+		s.counting.counter.tracker.clear()
+	}
+}
+
+// lineCounts 当前计数，ok 为 false 表示未开启虚拟线计数
+func (s *Session) lineCounts() (counts LineCounts, ok bool) {
+	s.countingMu.Lock()
+	defer s.countingMu.Unlock()
+	if s.counting.counter == nil {
+		return LineCounts{}, false
+	}
+	s.counting.counter.resetIfDue(time.Now())
+	return s.counting.counter.snapshot(), true
+}
+
+func (s *Session) resetLineCounts() (LineCounts, bool) {
+	s.countingMu.Lock()
+	defer s.countingMu.Unlock()
+	if s.counting.counter == nil {
+		return LineCounts{}, false
+	}
+	s.counting.counter.resetCounts(time.Now())
+	return s.counting.counter.snapshot(), true
+}
+
+func (s *Session) lineCountingDesc() *LineCounting {
+	s.countingMu.Lock()
+	defer s.countingMu.Unlock()
+	return s.counting.spec
+}
+
+// drawLineCounts 在推流画面上绘制虚拟线与进出计数，叠加文字仅在计数变化时重建
+func (s *Session) drawLineCounts(img *gocv.Mat) {
+	s.countingMu.Lock()
+	counter := s.counting.counter
+	if counter == nil {
+		s.countingMu.Unlock()
+		return
+	}
+	counter.resetIfDue(time.Now())
+	if s.counting.overlay == nil || s.counting.overlayVersion != counter.version {
+		overlay := make([]string, len(counter.spec.Lines))
+		for i, line := range counter.spec.Lines {
+			total := counter.lineTotals(i)
+			overlay[i] = fmt.Sprintf("%s in:%d out:%d", line.Name, total.In, total.Out)
+		}
+		s.counting.overlay, s.counting.overlayVersion = overlay, counter.version
+	}
+	lines, overlay := s.counting.spec.Lines, s.counting.overlay
+	s.countingMu.Unlock()
+
+	for i, line := range lines {
+		_ = gocv.Line(img, image.Pt(line.X1, line.Y1), image.Pt(line.X2, line.Y2), color.RGBA{255, 255, 0, 0}, 2)
+		_ = gocv.PutText(img, overlay[i], image.Pt(line.X1, line.Y1-10),
+			gocv.FontHersheyPlain, 1.2, color.RGBA{255, 255, 0, 0}, 2)
+	}
+}
+
+// LineCounts 获取会话虚拟线计数
+func (s *SessionManager) LineCounts(ctx context.Context, id string) (LineCounts, error) {
+	_session, exists := s.loadSession(ctx, id)
+	if !exists {
+		return LineCounts{}, fmt.Errorf("%w: %s", ErrSessionNotExists, id)
+	}
+	counts, ok := _session.lineCounts()
+	if !ok {
+		return LineCounts{}, fmt.Errorf("%w: session has no counting lines: %s", ErrInvalidLineCounting, id)
+	}
+	return counts, nil
+}
+
+// ResetLineCounts 手动清零会话虚拟线计数，返回清零后的计数
+func (s *SessionManager) ResetLineCounts(ctx context.Context, id string) (LineCounts, error) {
+	_session, exists := s.loadSession(ctx, id)
+	if !exists {
+		return LineCounts{}, fmt.Errorf("%w: %s", ErrSessionNotExists, id)
+	}
+	counts, ok := _session.resetLineCounts()
+	if !ok {
+		return LineCounts{}, fmt.Errorf("%w: session has no counting lines: %s", ErrInvalidLineCounting, id)
+	}
+	return counts, nil
+}
+
+var metricLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// WriteLineCountMetrics 以 Prometheus 文本格式输出调用方可见会话的虚拟线计数
+func (s *SessionManager) WriteLineCountMetrics(ctx context.Context, w io.Writer) error {
+	type sessionCounts struct {
+		id, tenant string
+		counts     LineCounts
+	}
+	principal := auth.FromContext(ctx)
+	list := make([]sessionCounts, 0)
+	s.sessions.Range(func(key string, _session *Session) bool {
+		if !principal.CanAccess(_session.tenant) {
+			return true
+		}
+		if counts, ok := _session.lineCounts(); ok {
+			list = append(list, sessionCounts{id: key, tenant: _session.tenant, counts: counts})
+		}
+		return true
+	})
+	slices.SortFunc(list, func(a, b sessionCounts) int { return strings.Compare(a.id, b.id) })
+
+	var sb strings.Builder
+	sb.WriteString("# HELP video_detect_line_crossings Objects crossing a counting line since the last reset.\n")
+	sb.WriteString("# TYPE video_detect_line_crossings gauge\n")
+	for _, item := range list {
+		for _, line := range item.counts.Lines {
+			labels := make([]string, 0, len(line.Labels))
+			for label := range line.Labels {
+				labels = append(labels, label)
+			}
+			slices.Sort(labels)
+			for _, label := range labels {
+				count := line.Labels[label]
+				for _, direction := range []struct {
+					name  string
+					value int64
+				}{{"in", count.In}, {"out", count.Out}} {
+					_, _ = fmt.Fprintf(&sb, "video_detect_line_crossings{session_id=\"%s\",tenant=\"%s\",line=\"%s\",label=\"%s\",direction=\"%s\"} %d\n",
+						metricLabelEscaper.Replace(item.id), metricLabelEscaper.Replace(item.tenant), metricLabelEscaper.Replace(line.Name),
+						metricLabelEscaper.Replace(label), direction.name, direction.value)
+				}
+			}
+		}
+	}
+	sb.WriteString("# HELP video_detect_line_counts_since_seconds Unix time the current counting period started.\n")
+	sb.WriteString("# TYPE video_detect_line_counts_since_seconds gauge\n")
+	for _, item := range list {
+		_, _ = fmt.Fprintf(&sb, "video_detect_line_counts_since_seconds{session_id=\"%s\",tenant=\"%s\"} %d\n",
+			metricLabelEscaper.Replace(item.id), metricLabelEscaper.Replace(item.tenant), item.counts.Since.Unix())
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
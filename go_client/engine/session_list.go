@@ -0,0 +1,78 @@
+package engine
+
+import (
+	"sort"
+	"strings"
+)
+
+// SessionFilter 会话列表过滤条件，零值字段不过滤
+type SessionFilter struct {
+	State        string   // 会话状态 preparing running reconnecting failed stopped
+	Site         string   // 站点
+	DetectStatus *bool    // 识别状态
+	Labels       []string // 标签 key 或 key=value，需全部匹配
+	Query        string   // ID、名称或拉流地址子串
+}
+
+func (f SessionFilter) match(s *Session) bool {
+	if f.State != "" && s.State().String() != f.State {
+		return false
+	}
+	if f.Site != "" && s.site != f.Site {
+		return false
+	}
+	if f.DetectStatus != nil && s.detectStatus.Load() != *f.DetectStatus {
+		return false
+	}
+	for _, label := range f.Labels {
+		key, value, hasValue := strings.Cut(label, "=")
+		v, ok := s.labels[key]
+		if !ok || (hasValue && v != value) {
+			return false
+		}
+	}
+	if f.Query != "" {
+		s.pipeMu.RLock()
+		rtspURL := s.rtspURL
+		s.pipeMu.RUnlock()
+		if !strings.Contains(s.id, f.Query) && !strings.Contains(s.name, f.Query) && !strings.Contains(rtspURL, f.Query) {
+			return false
+		}
+	}
+	return true
+}
+
+// sortSessionDesc 按字段排序，- 前缀表示降序，相同时按 ID 排序
+func sortSessionDesc(descList []SessionDesc, by string) {
+	field, desc := strings.CutPrefix(by, "-")
+	less := func(a, b SessionDesc) bool {
+		switch field {
+		case "state":
+			if a.State != b.State {
+				return a.State < b.State
+			}
+		case "uptime":
+			if a.UptimeSeconds != b.UptimeSeconds {
+				return a.UptimeSeconds < b.UptimeSeconds
+			}
+		}
+		return a.ID < b.ID
+	}
+	sort.Slice(descList, func(i, j int) bool {
+		if desc {
+			return less(descList[j], descList[i])
+		}
+		return less(descList[i], descList[j])
+	})
+}
+
+// paginate 按页截取，limit 为 0 时返回全部
+func paginate[T any](list []T, offset, limit int) []T {
+	if limit <= 0 {
+		return list
+	}
+	if offset >= len(list) {
+		return list[:0]
+	}
+	return list[offset:min(offset+limit, len(list))]
+}
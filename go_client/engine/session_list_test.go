@@ -0,0 +1,70 @@
+package engine
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPaginate(t *testing.T) {
+	list := []int{0, 1, 2, 3, 4, 5, 6}
+	tests := []struct {
+		name          string
+		offset, limit int
+		want          []int
+	}{
+		{"limit 0 returns all", 0, 0, list},
+		{"first page", 0, 5, []int{0, 1, 2, 3, 4}},
+		{"last partial page", 5, 5, []int{5, 6}},
+		{"offset at end", 7, 5, []int{}},
+		{"offset past end", 20, 5, []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := paginate(list, tt.offset, tt.limit); !slices.Equal(got, tt.want) {
+				t.Fatalf("paginate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSessionFilterMatch(t *testing.T) {
+	session := &Session{
+		id:      "cam-1",
+		name:    "north gate",
+		site:    "hq",
+		rtspURL: "rtsp://10.0.0.1/live",
+		labels:  map[string]string{"zone": "north", "ptz": ""},
+	}
+	session.status.reset()
+	session.status.setState(SessionStateRunning)
+	session.detectStatus.Store(true)
+	detectOff := false
+
+	tests := []struct {
+		name   string
+		filter SessionFilter
+		match  bool
+	}{
+		{"zero filter", SessionFilter{}, true},
+		{"state", SessionFilter{State: "running"}, true},
+		{"state mismatch", SessionFilter{State: "failed"}, false},
+		{"site mismatch", SessionFilter{Site: "branch"}, false},
+		{"detect status mismatch", SessionFilter{DetectStatus: &detectOff}, false},
+		{"label key without value", SessionFilter{Labels: []string{"zone"}}, true},
+		{"label key with empty value", SessionFilter{Labels: []string{"ptz"}}, true},
+		{"label empty value must equal", SessionFilter{Labels: []string{"zone="}}, false},
+		{"label key and value", SessionFilter{Labels: []string{"zone=north", "ptz="}}, true},
+		{"label value mismatch", SessionFilter{Labels: []string{"zone=south"}}, false},
+		{"missing label key", SessionFilter{Labels: []string{"floor"}}, false},
+		{"query by name", SessionFilter{Query: "gate"}, true},
+		{"query by rtsp url", SessionFilter{Query: "10.0.0.1"}, true},
+		{"query mismatch", SessionFilter{Query: "south"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.match(session); got != tt.match {
+				t.Fatalf("match() = %v, want %v", got, tt.match)
+			}
+		})
+	}
+}
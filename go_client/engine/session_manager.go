@@ -2,68 +2,172 @@ package engine
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	"go_client/config"
+	"go_client/pkg/auth"
+	"go_client/pkg/logger"
 	"go_client/pkg/map_utils"
+	"go_client/pkg/tracing"
 	"runtime/debug"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
+var (
+	ErrSessionNotExists  = errors.New("session not exists")
+	ErrSessionExists     = errors.New("session already exists")
+	ErrSessionNotRunning = errors.New("session not running")
+	ErrSessionDeclared   = errors.New("session is declared in config")
+	ErrInvalidEncoding   = errors.New("invalid encoding profile")
+)
+
+// 逐帧日志采样：每秒每条消息前 frameLogFirst 条全部输出，之后每 frameLogThereafter 条输出一条
+const (
+	frameLogFirst      = 5
+	frameLogThereafter = 100
+)
+
 func GenPushURL(preURL, streamKey string) string {
 	return preURL + streamKey
 }
 
 type SessionManager struct {
-	pushUrlInternalPre string // 推流使用前缀 ：如 rtmp://rtmp-server/live/stream
-	pushUrlPublicPre   string // 播放展示用：如 rtmp://mydomain.com/live/stream
+	pushUrlInternalPre atomic.Pointer[string] // 推流使用前缀 ：如 rtmp://rtmp-server/live/stream，热更新后对新会话生效
+	pushUrlPublicPre   atomic.Pointer[string] // 播放展示用：如 rtmp://mydomain.com/live/stream
 	ctx                context.Context
 	cancel             context.CancelFunc
 	logger             *zap.Logger
-	cfg                *config.Config
+	cfg                atomic.Pointer[config.Config] // 可热更新，通过 Config 读取
 	sessionPool        sync.Pool
 	sessions           *map_utils.Map[string, *Session]
 	closeCh            chan string
-	healthyHeartbeat   int32
+	healthyHeartbeat   atomic.Int32
+	encoders           map[string]bool // ffmpeg -encoders 探测到的可用编码器，nil 表示未探测
+	admitMu            sync.Mutex      // 串行化配额检查与会话登记
+	eventHandlers      []DetectionEventHandler
+	declared           atomic.Pointer[[]config.DeclaredSession] // 配置文件声明的会话，nil 表示不调和
+	reconcileCh        chan struct{}
+	auditLog           *AuditLog         // 会话生命周期审计日志，nil 表示未开启
+	detectStats        *DetectStatsStore // 识别统计，nil 表示未开启
+	eventStore         *EventStore       // 识别事件历史，nil 表示未开启
+	eventWebhook       *EventWebhook     // 识别事件推送，nil 表示未开启
 }
 
 func NewSessionManager(ctx context.Context, canalFunc context.CancelFunc, logger *zap.Logger, cfg *config.Config, healthyHeartbeat int32, pushUrlInternalPre, pushUrlPublicPre string) *SessionManager {
-	return &SessionManager{
-		pushUrlInternalPre: pushUrlInternalPre,
-		pushUrlPublicPre:   pushUrlPublicPre,
-		ctx:                ctx,
-		cancel:             canalFunc,
-		logger:             logger,
+	manager := &SessionManager{
+		ctx:    ctx,
+		cancel: canalFunc,
+		logger: logger,
 		sessionPool: sync.Pool{
 			New: func() interface{} {
 				return new(Session)
 			}},
-		sessions: map_utils.New[string, *Session](),
-		closeCh:  make(chan string, 128),
+		sessions:    map_utils.New[string, *Session](),
+		closeCh:     make(chan string, 128),
+		reconcileCh: make(chan struct{}, 1),
 		//rwLock:   new(sync.RWMutex),
-		cfg:              cfg,
-		healthyHeartbeat: healthyHeartbeat,
 	}
+	manager.cfg.Store(cfg)
+	manager.healthyHeartbeat.Store(healthyHeartbeat)
+	manager.pushUrlInternalPre.Store(&pushUrlInternalPre)
+	manager.pushUrlPublicPre.Store(&pushUrlPublicPre)
+	return manager
+}
+
+// Config 当前生效的配置
+func (s *SessionManager) Config() *config.Config {
+	return s.cfg.Load()
+}
+
+func (s *SessionManager) heartbeat() time.Duration {
+	return time.Second * time.Duration(s.healthyHeartbeat.Load())
+}
+
+func (s *SessionManager) publicPre() string {
+	return *s.pushUrlPublicPre.Load()
+}
+
+// ApplyConfig 热更新配置：识别默认值、uvicorn socket、编码配置与配额对新会话生效；
+// 仍使用旧默认识别地址的会话切换到新地址；推流前缀对新会话生效；心跳周期在下一个周期生效。
+// 心跳周期不大于 0 时拒绝更新，保留当前配置
+func (s *SessionManager) ApplyConfig(cfg *config.Config) error {
+	if cfg.Engine.HealthyHeartbeat <= 0 {
+		return fmt.Errorf("engine.healthy-heartbeat must be greater than 0, got %d", cfg.Engine.HealthyHeartbeat)
+	}
+	old := s.cfg.Swap(cfg)
+	if cfg.Engine.DetectAIURL != old.Engine.DetectAIURL {
+		s.sessions.Range(func(key string, _session *Session) bool {
+			if _session.aiURL.CompareAndSwap(old.Engine.DetectAIURL, cfg.Engine.DetectAIURL) {
+				s.logger.Info("🔧 Session ai url updated", zap.String("session_id", key))
+			}
+			return true
+		})
+	}
+	s.healthyHeartbeat.Store(cfg.Engine.HealthyHeartbeat)
+	internalPre, publicPre := cfg.Engine.PushUrlInternalPre, cfg.Engine.PushUrlPublicPre
+	s.pushUrlInternalPre.Store(&internalPre)
+	s.pushUrlPublicPre.Store(&publicPre)
+	return nil
+}
+
+// OnDetection 注册识别事件处理函数，需在 Run 之前调用
+func (s *SessionManager) OnDetection(handler DetectionEventHandler) {
+	s.eventHandlers = append(s.eventHandlers, handler)
 }
 
 func (s *SessionManager) Run() {
 	go s.closeChRecv()
 	go s.checkHealthySession()
+	go s.watchdogLoop()
+	go s.scheduleLoop()
+	go s.reconcileLoop()
 }
 
-func (s *SessionManager) Close() {
-	s.logger.Info("sessionManager close...")
+// Shutdown 并行优雅停止所有会话：关闭推流 stdin 使 FFmpeg 完成剩余写入，ctx 结束后强制结束
+func (s *SessionManager) Shutdown(ctx context.Context) error {
+	s.logger.Info("sessionManager shutdown...")
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
 	s.sessions.Range(func(key string, _session *Session) bool {
-		_session.cancelFunc()
-		s.sessions.Delete(key)
-		_session.Reset()
-		s.sessionPool.Put(_session)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := _session.Shutdown(ctx); err != nil {
+				s.logger.Warn("session shutdown timeout, ffmpeg killed", zap.String("session_id", key), zap.Error(err))
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("session %s: %w", key, err))
+				mu.Unlock()
+			}
+			s.releaseSession(key, _session)
+		}()
 		return true
 	})
+	wg.Wait()
 	s.cancel()
+	return errors.Join(errs...)
+}
+
+// releaseSession 删除会话、重置并放回池中，同一会话只会被释放一次
+func (s *SessionManager) releaseSession(id string, _session *Session) bool {
+	if !s.sessions.CompareAndDelete(id, _session) {
+		return false
+	}
+	_session.runningStatus.Store(false)
+	_session.cancelFunc()
+	_session.Reset()
+	s.sessionPool.Put(_session)
+	return true
 }
 
 // closeChRecv 接收关闭session并处理
@@ -79,13 +183,9 @@ func (s *SessionManager) closeChRecv() {
 			if !exists {
 				continue
 			}
-			s.logger.Info(fmt.Sprintf(`📴 Stream session "%v" 关闭会话并清除`, id))
+			// 保留已结束的会话以便查询状态，由健康检查在一个心跳周期后清除
+			s.logger.Info("📴 Stream session 会话结束", zap.String("session_id", id), zap.String("state", _session.State().String()))
 			_session.cancelFunc()
-			s.sessions.Delete(id)
-
-			// 重置_session 并放回池中
-			_session.Reset()
-			s.sessionPool.Put(_session)
 		}
 
 	}
@@ -94,24 +194,26 @@ func (s *SessionManager) closeChRecv() {
 // CheckHealthySession 检查会话健康
 func (s *SessionManager) checkHealthySession() {
 	s.logger.Info("session manager checkHealthySession running...")
-	tick := time.Tick(time.Second * time.Duration(s.healthyHeartbeat))
+	heartbeat := s.heartbeat()
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
 	for {
 		select {
 		case <-s.ctx.Done():
 			s.logger.Info("🛑 SessionManager 会话健康检查关闭")
 			return
-		case <-tick:
+		case <-ticker.C:
+			if current := s.heartbeat(); current != heartbeat {
+				heartbeat = current
+				ticker.Reset(heartbeat)
+			}
 			s.sessions.Range(func(key string, _session *Session) bool {
-				if !_session.runningStatus.Load() {
-					// 避免重复 cancel 和清理
-					if _session.handledClose.CompareAndSwap(false, true) {
-						_session.cancelFunc()
-						s.sessions.Delete(key)
-						_session.Reset()
-						s.sessionPool.Put(_session)
-
-						s.logger.Info("🧹 Tick 清理非运行 Session", zap.String("id", _session.id))
+				// 已结束超过一个心跳周期的会话清除
+				if tenant := _session.tenant; !_session.runningStatus.Load() && time.Since(_session.StoppedAt()) >= heartbeat {
+					if s.releaseSession(key, _session) {
+						s.auditLog.Append(AuditEvent{SessionID: key, Tenant: tenant, Action: AuditRemoved, Actor: AuditActorSystem, Reason: "cleanup after stopped"})
 					}
+					s.logger.Info("🧹 Tick 清理非运行 Session", zap.String("session_id", key), zap.String("state", _session.State().String()))
 				}
 				return true
 			})
@@ -119,39 +221,100 @@ func (s *SessionManager) checkHealthySession() {
 	}
 }
 
-func (s *SessionManager) CreateSession(id, rtsp, aiURL string, options ...SetSessionOption) (desc SessionDesc, err error) {
-	if _, exists := s.sessions.Load(id); exists {
-		return desc, fmt.Errorf("session already started: %s", id)
+// loadSession 按调用方租户加载会话，无权访问的会话视为不存在
+func (s *SessionManager) loadSession(ctx context.Context, id string) (*Session, bool) {
+	_session, exists := s.sessions.Load(id)
+	if !exists || !auth.FromContext(ctx).CanAccess(_session.tenant) {
+		return nil, false
+	}
+	return _session, true
+}
+
+// CreateSession 创建会话，会话归属调用方租户
+func (s *SessionManager) CreateSession(ctx context.Context, id, rtsp, aiURL string, options ...SetSessionOption) (desc SessionDesc, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "SessionManager.CreateSession", trace.WithAttributes(
+		attribute.String("session.id", id),
+		attribute.String("tenant", auth.FromContext(ctx).GetTenant()),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	if _session, exists := s.sessions.Load(id); exists {
+		// 其他租户的同名会话与运行中的会话返回相同错误，不泄露会话归属
+		if _session.runningStatus.Load() || !auth.FromContext(ctx).CanAccess(_session.tenant) {
+			return desc, fmt.Errorf("%w: %s", ErrSessionExists, id)
+		}
+		// 已结束的会话直接替换
+		s.releaseSession(id, _session)
 	}
 
-	ctx, cancel := context.WithCancel(s.ctx)
+	sessionCtx, cancel := context.WithCancel(s.ctx)
 
 	session := s.sessionPool.Get().(*Session)
 	session.id = id
+	session.tenant = auth.FromContext(ctx).GetTenant()
 	session.rtspURL = rtsp
+	session.aiURL.Store(aiURL)
 	session.cancelFunc = cancel
-	session.ctx = ctx
-	session.logger = s.logger
+	session.ctx = sessionCtx
 	session.closeCh = s.closeCh
+	session.eventHandlers = s.eventHandlers
+	session.auditLog = s.auditLog
 
 	session.streamKey = uuid.New().String()
+	// 会话调试模式下放开 debug 等级，不影响其他会话
+	session.logger = logger.WithLevelOverride(s.logger.With(zap.String("session_id", id), zap.String("stream_key", session.streamKey)),
+		func(level zapcore.Level) bool {
+			return session.debug.Load() && level >= zapcore.DebugLevel
+		})
+	session.frameLogger = logger.Sampled(session.logger, frameLogFirst, frameLogThereafter)
 
 	session.SetSessionWithOptions(options...)
+	// 先确定分辨率与帧率，虚拟线校验与准入成本均基于实际使用的值
+	session.resolveVideoDefaults()
+	if err := errors.Join(session.setSchedule(session.schedule.spec), session.setLineCounting(session.counting.spec)); err != nil {
+		cancel()
+		session.Reset()
+		s.sessionPool.Put(session)
+		return desc, err
+	}
 	session.resultCache = &DetectionResultCache{
 		RWMutex: sync.RWMutex{},
 		Results: make([]DetectionResult, 0),
 	}
-	session.frameForDetection = make(chan []byte, 32)
+	session.frameForDetection = make(chan detectFrame, 32)
 
-	s.sessions.Store(id, session)
+	s.admitMu.Lock()
+	if err := s.admit(session.tenant, "", session.cost()); err != nil {
+		s.admitMu.Unlock()
+		cancel()
+		session.Reset()
+		s.sessionPool.Put(session)
+		s.logger.Warn("⛔ Session rejected", zap.String("session_id", id), zap.String("tenant", session.tenant), zap.Error(err))
+		return desc, err
+	}
+	// 会话对其他 goroutine 可见前创建 runDone，并发删除时 Reset 会等待 Run 退出
+	session.runDone = make(chan struct{})
+	_, loaded := s.sessions.LoadOrStore(id, session)
+	s.admitMu.Unlock()
+	if loaded {
+		cancel()
+		session.runDone = nil
+		session.Reset()
+		s.sessionPool.Put(session)
+		return desc, fmt.Errorf("%w: %s", ErrSessionExists, id)
+	}
+	s.audit(ctx, id, session.tenant, AuditCreated, "")
 
-	pushURL := GenPushURL(s.pushUrlInternalPre, session.streamKey)
-	if err := session.PrepareStream(pushURL); err != nil {
-		s.sessions.Delete(id)
+	pushURL := GenPushURL(*s.pushUrlInternalPre.Load(), session.streamKey)
+	if err := session.PrepareStream(ctx, pushURL); err != nil {
+		// Run 不会启动，关闭 runDone 避免 Reset 阻塞
+		close(session.runDone)
+		s.releaseSession(id, session)
 		return desc, fmt.Errorf("failed to prepare stream: %w", err)
 	}
+	s.audit(ctx, id, session.tenant, AuditPrepared, "")
 
-	s.logger.Info("🚀 Session started", zap.String("id", id), zap.String("rtsp", rtsp), zap.String("pushRTMPURL", pushURL))
+	s.logger.Info("🚀 Session started", zap.String("session_id", id), zap.String("rtsp", rtsp), zap.String("pushRTMPURL", pushURL))
 
 	go func() {
 		defer func() {
@@ -159,21 +322,24 @@ func (s *SessionManager) CreateSession(id, rtsp, aiURL string, options ...SetSes
 				s.logger.Error("panic recovered in Session.Run", zap.Any("error", r), zap.ByteString("stack", debug.Stack()))
 			}
 		}()
-		session.Run(aiURL, s.cfg.Engine.UvicornSocket, s.cfg.Engine.SocketPath)
+		engineCfg := s.Config().Engine
+		session.Run(engineCfg.UvicornSocket, engineCfg.SocketPath)
 	}()
 
-	desc = session.GetDesc(s.pushUrlPublicPre)
+	desc = session.GetDesc(s.publicPre())
 
 	return desc, nil
 }
 
-func (s *SessionManager) GetSessionDescList() []SessionDesc {
+// GetSessionDescList 获取调用方租户可见的会话描述列表
+func (s *SessionManager) GetSessionDescList(ctx context.Context) []SessionDesc {
+	principal := auth.FromContext(ctx)
 	descList := make([]SessionDesc, 0)
 	s.sessions.Range(func(key string, _session *Session) bool {
-		if !_session.runningStatus.Load() {
+		if !principal.CanAccess(_session.tenant) {
 			return true
 		}
-		descList = append(descList, _session.GetDesc(s.pushUrlPublicPre))
+		descList = append(descList, _session.GetDesc(s.publicPre()))
 		return true
 	})
 
@@ -184,47 +350,242 @@ func (s *SessionManager) GetSessionDescList() []SessionDesc {
 	return descList
 }
 
-func (s *SessionManager) StopSessionRun(id string) error {
-	if session, exists := s.sessions.Load(id); exists {
+// ListSessionDesc 按条件过滤、排序并分页获取调用方租户可见的会话描述，返回过滤后的总数
+func (s *SessionManager) ListSessionDesc(ctx context.Context, filter SessionFilter, sortBy string, offset, limit int) ([]SessionDesc, int) {
+	principal := auth.FromContext(ctx)
+	descList := make([]SessionDesc, 0)
+	s.sessions.Range(func(key string, _session *Session) bool {
+		if !principal.CanAccess(_session.tenant) || !filter.match(_session) {
+			return true
+		}
+		descList = append(descList, _session.GetDesc(s.publicPre()))
+		return true
+	})
+
+	sortSessionDesc(descList, sortBy)
+	return paginate(descList, offset, limit), len(descList)
+}
+
+func (s *SessionManager) StopSessionRun(ctx context.Context, id string) error {
+	if session, exists := s.loadSession(ctx, id); exists {
+		// 先切换状态，Run 退出时不再记为内部停止
+		if session.status.setState(SessionStateStopped) {
+			s.audit(ctx, id, session.tenant, AuditStopped, "")
+		}
 		session.cancelFunc()
 		return nil
 	}
 	return fmt.Errorf("Session 不存在: %s", id)
 }
 
-func (s *SessionManager) StopSessionDetect(id string) error {
-	if session, exists := s.sessions.Load(id); exists {
-		session.detectStatus.Store(false)
-		return nil
+// ResolveEncodingProfile 以默认编码配置为基础合并命名编码配置与覆盖项
+func (s *SessionManager) ResolveEncodingProfile(name string, override *config.EncodingProfile) (config.EncodingProfile, error) {
+	return s.resolveEncoding(s.Config().Engine.Encoding, name, override)
+}
+
+// resolveEncoding 以 base 为基础依次合并命名编码配置与覆盖项，并校验编码器可用
+func (s *SessionManager) resolveEncoding(base config.EncodingProfile, name string, override *config.EncodingProfile) (config.EncodingProfile, error) {
+	engineCfg := s.Config().Engine
+	profile := base
+	if name != "" {
+		named, ok := engineCfg.EncodingProfiles[name]
+		if !ok {
+			return profile, fmt.Errorf("%w: profile not exists: %s", ErrInvalidEncoding, name)
+		}
+		profile = engineCfg.Encoding.Merge(named)
 	}
+	if override != nil {
+		profile = profile.Merge(*override)
+	}
+
+	if err := profile.Validate(); err != nil {
+		return profile, fmt.Errorf("%w: %w", ErrInvalidEncoding, err)
+	}
+
+	codec := profile.Codec
+	if codec == "" {
+		codec = config.CodecH264
+	}
+	if s.encoders != nil && !s.encoders[codec] {
+		return profile, fmt.Errorf("%w: encoder not available in ffmpeg: %s", ErrInvalidEncoding, codec)
+	}
+	return profile, nil
+}
+
+// UpdateSession 运行时更新会话配置，streamKey 保持不变
+func (s *SessionManager) UpdateSession(ctx context.Context, id string, update SessionUpdate) (SessionDesc, error) {
+	_session, exists := s.loadSession(ctx, id)
+	if !exists {
+		return SessionDesc{}, fmt.Errorf("%w: %s", ErrSessionNotExists, id)
+	}
+
+	// 编码配置以会话当前配置为基础合并
+	if update.EncodingProfile != nil || update.Encoding != nil {
+		var name string
+		if update.EncodingProfile != nil {
+			name = *update.EncodingProfile
+		}
+		_session.pipeMu.RLock()
+		base := _session.encoding
+		_session.pipeMu.RUnlock()
+
+		encoding, err := s.resolveEncoding(base, name, update.Encoding)
+		if err != nil {
+			return SessionDesc{}, err
+		}
+		update.EncodingProfile, update.Encoding = nil, &encoding
+	}
+
+	// 更新后的分辨率与帧率
+	_session.pipeMu.RLock()
+	width, height, framerate := _session.width, _session.height, _session.framerate
+	_session.pipeMu.RUnlock()
+	if update.Width != nil {
+		width = *update.Width
+	}
+	if update.Height != nil {
+		height = *update.Height
+	}
+	if update.Framerate != nil {
+		framerate = *update.Framerate
+	}
+	resized := update.Width != nil || update.Height != nil
+
+	// 分辨率或帧率变化时按新成本重新做准入检查，持有准入锁直到更新完成，避免并发创建或更新超出配额
+	if resized || update.Framerate != nil {
+		s.admitMu.Lock()
+		defer s.admitMu.Unlock()
+		if err := s.admit(_session.tenant, id, sessionCost(width, height, framerate)); err != nil {
+			return SessionDesc{}, err
+		}
+	}
+
+	// 识别时间计划先校验，会话更新成功后再生效
+	var schedule *detectSchedule
+	if update.Schedule != nil {
+		var err error
+		if schedule, err = update.Schedule.compile(); err != nil {
+			return SessionDesc{}, err
+		}
+	}
+	// 虚拟线按更新后的分辨率校验，会话更新成功后再生效并清零计数；仅修改分辨率时校验现有的线
+	var counter *lineCounter
+	if update.Counting != nil {
+		if err := update.Counting.checkBounds(width, height); err != nil {
+			return SessionDesc{}, err
+		}
+		var err error
+		if counter, err = update.Counting.compile(time.Now()); err != nil {
+			return SessionDesc{}, err
+		}
+	} else if resized {
+		if err := _session.lineCountingDesc().checkBounds(width, height); err != nil {
+			return SessionDesc{}, err
+		}
+	}
+
+	detectBefore := _session.detectStatus.Load()
+	if err := _session.Update(update); err != nil {
+		return SessionDesc{}, fmt.Errorf("failed to update session: %w", err)
+	}
+	if update.Schedule != nil {
+		_session.applySchedule(schedule)
+	}
+	if update.Counting != nil {
+		_session.applyLineCounting(counter)
+	} else if resized {
+		_session.clearLineTracks()
+	}
+	s.audit(ctx, id, _session.tenant, AuditUpdated, "")
+	if update.DetectStatus != nil {
+		_session.setScheduleOverride(*update.DetectStatus, time.Time{})
+		if *update.DetectStatus != detectBefore {
+			s.auditDetect(ctx, _session, *update.DetectStatus)
+		}
+	}
+
+	s.logger.Info("🔧 Session updated", zap.String("session_id", id))
+	return _session.GetDesc(s.publicPre()), nil
+}
+
+func (s *SessionManager) StopSessionDetect(ctx context.Context, id string) error {
+	session, exists := s.loadSession(ctx, id)
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrSessionNotExists, id)
+	}
+	s.setDetectStatus(ctx, session, false)
 	return nil
 }
 
-func (s *SessionManager) StartSessionDetect(id string) error {
-	if session, exists := s.sessions.Load(id); exists {
-		session.detectStatus.Store(true)
-		return nil
+func (s *SessionManager) StartSessionDetect(ctx context.Context, id string) error {
+	session, exists := s.loadSession(ctx, id)
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrSessionNotExists, id)
 	}
+	s.setDetectStatus(ctx, session, true)
 	return nil
 }
 
-func (s *SessionManager) RemoveSession(id string) {
-	_session, exists := s.sessions.Load(id)
+// setDetectStatus 开启或暂停识别，状态变化时记录审计事件
+func (s *SessionManager) setDetectStatus(ctx context.Context, _session *Session, detect bool) {
+	// 设置了识别时间计划的会话，手动操作到计划的下一次切换为止
+	_session.setScheduleOverride(detect, time.Time{})
+	if _session.detectStatus.Swap(detect) != detect {
+		s.auditDetect(ctx, _session, detect)
+	}
+}
+
+func (s *SessionManager) auditDetect(ctx context.Context, _session *Session, detect bool) {
+	action := AuditDetectStopped
+	if detect {
+		action = AuditDetectStarted
+	}
+	s.audit(ctx, _session.id, _session.tenant, action, "")
+}
+
+func (s *SessionManager) RemoveSession(ctx context.Context, id string) error {
+	_session, exists := s.loadSession(ctx, id)
 	if !exists {
-		return
+		return fmt.Errorf("%w: %s", ErrSessionNotExists, id)
 	}
-	_session.runningStatus.Store(false)
-	s.sessions.Delete(id)
-	_session.cancelFunc()
-	_session.Reset()
-	s.sessionPool.Put(_session)
+	return s.removeUndeclaredSession(ctx, id, _session)
 }
 
-func (s *SessionManager) GetSessionDescByID(id string) (SessionDesc, bool) {
-	_session, exists := s.sessions.Load(id)
+// removeUndeclaredSession 通过 API 删除会话；配置文件声明的会话会被调和重新创建，需从配置中移除
+func (s *SessionManager) removeUndeclaredSession(ctx context.Context, id string, _session *Session) error {
+	if _session.Declared() != nil {
+		return fmt.Errorf("%w: %s, remove it from the config file instead", ErrSessionDeclared, id)
+	}
+	if !s.removeSession(ctx, id, _session, "") {
+		return fmt.Errorf("%w: %s", ErrSessionNotExists, id)
+	}
+	return nil
+}
+
+// removeSession 删除会话并记录审计事件，会话已被删除时返回 false
+func (s *SessionManager) removeSession(ctx context.Context, id string, _session *Session, reason string) bool {
+	tenant := _session.tenant
+	if !s.releaseSession(id, _session) {
+		return false
+	}
+	s.audit(ctx, id, tenant, AuditRemoved, reason)
+	return true
+}
+
+// GetSessionFFmpegLogs 获取会话最近的 FFmpeg stderr 日志
+func (s *SessionManager) GetSessionFFmpegLogs(ctx context.Context, id, source string, limit int) ([]FFmpegLogLine, error) {
+	_session, exists := s.loadSession(ctx, id)
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrSessionNotExists, id)
+	}
+	return _session.FFmpegLogs(source, limit), nil
+}
+
+func (s *SessionManager) GetSessionDescByID(ctx context.Context, id string) (SessionDesc, bool) {
+	_session, exists := s.loadSession(ctx, id)
 	if !exists {
 		return SessionDesc{}, false
 	}
 
-	return _session.GetDesc(s.pushUrlPublicPre), true
+	return _session.GetDesc(s.publicPre()), true
 }
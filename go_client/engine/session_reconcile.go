@@ -0,0 +1,159 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"go_client/config"
+	"go_client/pkg/auth"
+	"time"
+)
+
+const defaultDeclaredRetryTimes = 3
+
+// SetDeclaredSessions 设置配置文件声明的会话并触发调和
+func (s *SessionManager) SetDeclaredSessions(declared []config.DeclaredSession) {
+	s.declared.Store(&declared)
+	select {
+	case s.reconcileCh <- struct{}{}:
+	default:
+	}
+}
+
+// reconcileLoop 定时及声明变化时调和会话
+func (s *SessionManager) reconcileLoop() {
+	s.logger.Info("session manager reconcileLoop running...")
+	heartbeat := s.heartbeat()
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			s.logger.Info("🛑 SessionManager 会话调和关闭")
+			return
+		case <-s.reconcileCh:
+		case <-ticker.C:
+			if current := s.heartbeat(); current != heartbeat {
+				heartbeat = current
+				ticker.Reset(heartbeat)
+			}
+		}
+		s.reconcile()
+	}
+}
+
+// reconcile 将实际会话调和为声明状态：创建缺失或已结束的会话，更新声明变化的会话，删除不再声明的会话
+// 仅管理声明式会话，通过 API 创建的会话不受影响
+func (s *SessionManager) reconcile() {
+	declaredPtr := s.declared.Load()
+	if declaredPtr == nil {
+		return
+	}
+
+	desired := make(map[string]config.DeclaredSession, len(*declaredPtr))
+	for _, d := range *declaredPtr {
+		if _, dup := desired[d.ID]; dup {
+			s.logger.Warn("duplicate declared session ignored", zap.String("session_id", d.ID))
+			continue
+		}
+		if d.RetryTimes == 0 {
+			d.RetryTimes = defaultDeclaredRetryTimes
+		}
+		desired[d.ID] = d
+	}
+
+	// 删除不再声明的会话
+	s.sessions.Range(func(key string, _session *Session) bool {
+		if _, ok := desired[key]; !ok && _session.Declared() != nil {
+			ctx := auth.WithPrincipal(s.ctx, declaredPrincipal(_session.tenant))
+			if s.removeSession(ctx, key, _session, "no longer declared") {
+				s.logger.Info("🧹 Declared session removed", zap.String("session_id", key))
+			}
+		}
+		return true
+	})
+
+	for id, d := range desired {
+		if err := s.reconcileSession(d); err != nil {
+			s.logger.Warn("declared session reconcile failed", zap.String("session_id", id), zap.Error(err))
+		}
+	}
+}
+
+// declaredPrincipal 声明式会话的调用方身份
+func declaredPrincipal(tenant string) *auth.Principal {
+	return &auth.Principal{Subject: "config", Tenant: tenant, Method: auth.MethodConfig}
+}
+
+func (s *SessionManager) reconcileSession(d config.DeclaredSession) error {
+	ctx := auth.WithPrincipal(s.ctx, declaredPrincipal(d.Tenant))
+
+	_session, exists := s.sessions.Load(d.ID)
+	var declared *config.DeclaredSession
+	if exists {
+		declared = _session.Declared()
+	}
+	switch {
+	case exists && declared == nil:
+		return fmt.Errorf("session %s already created by api", d.ID)
+	case exists && _session.runningStatus.Load() && declared.Equal(d):
+		return nil
+	case exists && _session.runningStatus.Load() && declared.SameImmutable(d):
+		return s.updateDeclaredSession(ctx, _session, *declared, d)
+	case exists:
+		// 已结束或不可修改的字段变化，重建会话
+		s.removeSession(ctx, d.ID, _session, "declaration changed, recreating")
+	}
+
+	req := CreateSessionReq{
+		ID:              d.ID,
+		RtspURL:         d.RtspURL,
+		Width:           d.Width,
+		Height:          d.Height,
+		Framerate:       d.Framerate,
+		RetryTimes:      d.RetryTimes,
+		EncodingProfile: d.EncodingProfile,
+		Name:            d.Name,
+		Site:            d.Site,
+		Labels:          d.Labels,
+	}
+	if err := Validate(req); err != nil {
+		return err
+	}
+	encoding, err := s.ResolveEncodingProfile(req.EncodingProfile, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := s.CreateSession(ctx, d.ID, d.RtspURL, s.Config().Engine.DetectAIURL, append(req.Options(encoding), SetSessionDeclared(d))...); err != nil {
+		return err
+	}
+	s.logger.Info("📋 Declared session created", zap.String("session_id", d.ID))
+	if d.Detect {
+		return s.StartSessionDetect(ctx, d.ID)
+	}
+	return nil
+}
+
+func (s *SessionManager) updateDeclaredSession(ctx context.Context, _session *Session, old, d config.DeclaredSession) error {
+	update := SessionUpdate{DetectStatus: &d.Detect}
+	if d.RtspURL != old.RtspURL {
+		update.RtspURL = &d.RtspURL
+	}
+	if d.Width != old.Width || d.Height != old.Height || d.Framerate != old.Framerate {
+		update.Width, update.Height, update.Framerate = &d.Width, &d.Height, &d.Framerate
+	}
+	if d.EncodingProfile != old.EncodingProfile {
+		// 命名编码配置以默认编码配置为基础，清空时恢复默认编码配置
+		encoding, err := s.ResolveEncodingProfile(d.EncodingProfile, nil)
+		if err != nil {
+			return err
+		}
+		update.Encoding = &encoding
+	}
+	if _, err := s.UpdateSession(ctx, d.ID, update); err != nil {
+		return err
+	}
+	_session.setDeclared(&d)
+	s.logger.Info("📋 Declared session updated", zap.String("session_id", d.ID))
+	return nil
+}
@@ -0,0 +1,80 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"go_client/config"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// fakeFFmpeg 在 PATH 中放置一个不输出任何帧的 ffmpeg：拉流读帧会一直阻塞直到进程被结束，推流在 stdin 关闭后退出
+func fakeFFmpeg(t *testing.T) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake ffmpeg requires a POSIX shell")
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "ffmpeg"), []byte("#!/bin/sh\ncase \" $* \" in *' -y '*) exec cat >/dev/null ;; esac\nexec sleep 60\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestReconcileDeclaredSessions(t *testing.T) {
+	fakeFFmpeg(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cfg := &config.Config{}
+	cfg.Engine.DetectAIURL = "http://127.0.0.1:1/detect"
+	manager := NewSessionManager(ctx, cancel, zap.NewNop(), cfg, 60, "rtmp://127.0.0.1/live", "rtmp://127.0.0.1/live")
+	defer func() {
+		_ = manager.Shutdown(context.Background())
+		cancel()
+	}()
+
+	// 通过 API 创建的会话不受调和影响
+	manager.sessions.Store("api-1", &Session{id: "api-1"})
+
+	// 创建
+	declared := config.DeclaredSession{ID: "cam-1", RtspURL: "rtsp://10.0.0.1/live", Width: 640, Height: 480, Framerate: 15}
+	manager.SetDeclaredSessions([]config.DeclaredSession{declared})
+	manager.reconcile()
+	created, ok := manager.sessions.Load("cam-1")
+	if !ok {
+		t.Fatal("declared session not created")
+	}
+	if d := created.Declared(); d == nil || d.RetryTimes != defaultDeclaredRetryTimes || created.width != 640 {
+		t.Fatalf("unexpected declared session: declared=%+v width=%d", d, created.width)
+	}
+
+	// 声明式会话不能通过 API 删除
+	if err := manager.RemoveSession(ctx, "cam-1"); !errors.Is(err, ErrSessionDeclared) {
+		t.Fatalf("want ErrSessionDeclared, got %v", err)
+	}
+
+	// 可修改的字段变化时原地更新
+	declared.Width, declared.Height, declared.Detect = 1280, 720, true
+	manager.SetDeclaredSessions([]config.DeclaredSession{declared})
+	manager.reconcile()
+	updated, _ := manager.sessions.Load("cam-1")
+	if updated != created {
+		t.Fatal("declared session recreated, want in-place update")
+	}
+	if d := updated.Declared(); d.Width != 1280 || !d.Detect || updated.width != 1280 || !updated.detectStatus.Load() {
+		t.Fatalf("unexpected updated session: declared=%+v width=%d", d, updated.width)
+	}
+
+	// 不再声明时删除
+	manager.SetDeclaredSessions([]config.DeclaredSession{})
+	manager.reconcile()
+	if _, ok := manager.sessions.Load("cam-1"); ok {
+		t.Fatal("undeclared session not removed")
+	}
+	if _, ok := manager.sessions.Load("api-1"); !ok {
+		t.Fatal("api session removed by reconcile")
+	}
+	manager.sessions.Delete("api-1")
+}
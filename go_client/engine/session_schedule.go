@@ -0,0 +1,160 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"time"
+)
+
+const (
+	scheduleInterval        = time.Second    // 识别时间计划检查间隔
+	defaultOverrideDuration = 24 * time.Hour // 计划没有下一次切换时手动覆盖的有效期
+)
+
+// sessionSchedule 会话识别时间计划与手动覆盖
+type sessionSchedule struct {
+	spec     *DetectSchedule   // 原始计划，nil 表示不按计划
+	compiled *detectSchedule   // 已解析的计划
+	override *ScheduleOverride // 手动覆盖，nil 表示无
+}
+
+// setSchedule 设置识别时间计划并清除手动覆盖，spec 为 nil 或空计划表示取消计划
+func (s *Session) setSchedule(spec *DetectSchedule) error {
+	compiled, err := spec.compile()
+	if err != nil {
+		return err
+	}
+	s.applySchedule(compiled)
+	return nil
+}
+
+// applySchedule 使用已校验的计划替换当前计划并清除手动覆盖，compiled 为 nil 表示取消计划
+func (s *Session) applySchedule(compiled *detectSchedule) {
+	s.scheduleMu.Lock()
+	defer s.scheduleMu.Unlock()
+	s.schedule = sessionSchedule{compiled: compiled}
+	if compiled != nil {
+		s.schedule.spec = &compiled.spec
+	}
+}
+
+// setScheduleOverride 设置手动覆盖，until 为零值时到计划的下一次切换为止；未设置计划时不生效
+func (s *Session) setScheduleOverride(detect bool, until time.Time) bool {
+	s.scheduleMu.Lock()
+	defer s.scheduleMu.Unlock()
+	if s.schedule.compiled == nil {
+		return false
+	}
+	if until.IsZero() {
+		until = time.Now().Add(defaultOverrideDuration)
+		if next, ok := s.schedule.compiled.next(time.Now()); ok {
+			until = next.Time
+		}
+	}
+	s.schedule.override = &ScheduleOverride{Detect: detect, Until: until}
+	return true
+}
+
+func (s *Session) clearScheduleOverride() {
+	s.scheduleMu.Lock()
+	defer s.scheduleMu.Unlock()
+	s.schedule.override = nil
+}
+
+// scheduledDetect 按计划与手动覆盖计算 now 时刻应有的识别状态，ok 为 false 表示未设置计划；覆盖到期后清除
+func (s *Session) scheduledDetect(now time.Time) (detect bool, reason string, ok bool) {
+	s.scheduleMu.Lock()
+	defer s.scheduleMu.Unlock()
+	if s.schedule.compiled == nil {
+		return false, "", false
+	}
+	if override := s.schedule.override; override != nil {
+		if now.Before(override.Until) {
+			return override.Detect, "manual override until " + override.Until.Format(time.RFC3339), true
+		}
+		s.schedule.override = nil
+	}
+	return s.schedule.compiled.at(now), "schedule", true
+}
+
+// scheduleDesc 计划、手动覆盖与下一次识别状态切换
+func (s *Session) scheduleDesc(now time.Time) (*DetectSchedule, *ScheduleOverride, *ScheduleTransition) {
+	s.scheduleMu.Lock()
+	defer s.scheduleMu.Unlock()
+	if s.schedule.compiled == nil {
+		return nil, nil, nil
+	}
+	override := s.schedule.override
+	if override != nil && now.Before(override.Until) {
+		// 覆盖到期时恢复计划状态
+		if detect := s.schedule.compiled.at(override.Until); detect != override.Detect {
+			return s.schedule.spec, override, &ScheduleTransition{Time: override.Until, Detect: detect}
+		}
+		now = override.Until
+	}
+	next, ok := s.schedule.compiled.next(now)
+	if !ok {
+		return s.schedule.spec, override, nil
+	}
+	return s.schedule.spec, override, &next
+}
+
+// scheduleLoop 按识别时间计划开启或暂停识别，等同于调用 StartSessionDetect/StopSessionDetect
+func (s *SessionManager) scheduleLoop() {
+	s.logger.Info("session manager scheduleLoop running...")
+	ticker := time.NewTicker(scheduleInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			s.logger.Info("🛑 SessionManager 识别时间计划关闭")
+			return
+		case now := <-ticker.C:
+			s.sessions.Range(func(key string, _session *Session) bool {
+				if !_session.runningStatus.Load() {
+					return true
+				}
+				detect, reason, ok := _session.scheduledDetect(now)
+				if !ok || _session.detectStatus.Swap(detect) == detect {
+					return true
+				}
+				action := AuditDetectStopped
+				if detect {
+					action = AuditDetectStarted
+				}
+				_session.audit(action, reason)
+				s.logger.Info("⏰ Scheduled detect status changed", zap.String("session_id", key), zap.Bool("detect", detect), zap.String("reason", reason))
+				return true
+			})
+		}
+	}
+}
+
+// SetScheduleOverride 手动覆盖识别时间计划，until 为零值时到计划的下一次切换为止，不能早于当前时间
+func (s *SessionManager) SetScheduleOverride(ctx context.Context, id string, detect bool, until time.Time) (SessionDesc, error) {
+	_session, exists := s.loadSession(ctx, id)
+	if !exists {
+		return SessionDesc{}, fmt.Errorf("%w: %s", ErrSessionNotExists, id)
+	}
+	if !until.IsZero() && !until.After(time.Now()) {
+		return SessionDesc{}, fmt.Errorf("%w: override until is in the past: %s", ErrInvalidSchedule, until.Format(time.RFC3339))
+	}
+	if !_session.setScheduleOverride(detect, until) {
+		return SessionDesc{}, fmt.Errorf("%w: session has no schedule: %s", ErrInvalidSchedule, id)
+	}
+	if _session.detectStatus.Swap(detect) != detect {
+		s.auditDetect(ctx, _session, detect)
+	}
+	return _session.GetDesc(s.publicPre()), nil
+}
+
+// ClearScheduleOverride 取消手动覆盖，立即恢复按计划执行
+func (s *SessionManager) ClearScheduleOverride(ctx context.Context, id string) (SessionDesc, error) {
+	_session, exists := s.loadSession(ctx, id)
+	if !exists {
+		return SessionDesc{}, fmt.Errorf("%w: %s", ErrSessionNotExists, id)
+	}
+	_session.clearScheduleOverride()
+	return _session.GetDesc(s.publicPre()), nil
+}
@@ -0,0 +1,284 @@
+package engine
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SessionState 会话状态
+type SessionState int32
+
+const (
+	SessionStatePreparing    SessionState = iota // 准备中：启动拉流与推流 FFmpeg
+	SessionStateRunning                          // 运行中
+	SessionStateReconnecting                     // 重连中：拉流断开后重启拉流 FFmpeg
+	SessionStateFailed                           // 失败：异常退出
+	SessionStateStopped                          // 已停止：主动关闭
+)
+
+func (st SessionState) String() string {
+	switch st {
+	case SessionStatePreparing:
+		return "preparing"
+	case SessionStateRunning:
+		return "running"
+	case SessionStateReconnecting:
+		return "reconnecting"
+	case SessionStateFailed:
+		return "failed"
+	case SessionStateStopped:
+		return "stopped"
+	default:
+		return "unknown"
+	}
+}
+
+// IsTerminal 是否为终止状态
+func (st SessionState) IsTerminal() bool {
+	return st == SessionStateFailed || st == SessionStateStopped
+}
+
+const maxSessionErrorHistory = 16 // 会话错误历史保留条数
+
+// SessionError 会话错误记录，连续出现的相同已知错误合并为一条
+type SessionError struct {
+	Time     time.Time `json:"time"`           // 首次出现时间
+	Stage    string    `json:"stage"`          // 出错阶段：pull 拉流 push 推流 detect 识别 prepare 准备
+	Code     string    `json:"code,omitempty"` // 已知错误类型，如 FFmpeg 日志解析出的 unauthorized
+	Message  string    `json:"message"`        // 首次出现时的错误信息
+	Count    int       `json:"count"`          // 连续出现次数
+	LastSeen time.Time `json:"lastSeen"`       // 最近一次出现时间
+}
+
+// SessionStats 会话流统计
+type SessionStats struct {
+	InputFPS       float64 `json:"inputFps"`       // 拉流读帧帧率
+	OutputFPS      float64 `json:"outputFps"`      // 推流写帧帧率
+	DetectFPS      float64 `json:"detectFps"`      // 识别完成帧率
+	AvgAILatencyMs float64 `json:"avgAILatencyMs"` // 识别请求平均耗时 ms
+
+	LastFrameReadAt   time.Time `json:"lastFrameReadAt"`   // 最近一次读到帧的时间
+	LastFramePushedAt time.Time `json:"lastFramePushedAt"` // 最近一次推流写帧的时间
+}
+
+// sessionStatus 会话状态机、错误历史与流统计
+type sessionStatus struct {
+	state          atomic.Int32
+	reconnectCount atomic.Int64 // 累计重连次数
+	startedAt      atomic.Int64 // 启动时间 UnixNano
+	stoppedAt      atomic.Int64 // 停止时间 UnixNano
+
+	errMu  sync.RWMutex
+	errors []SessionError
+
+	inputMeter  rateMeter
+	outputMeter rateMeter
+	detectMeter rateMeter
+
+	aiLatencyTotal atomic.Int64 // 识别请求累计耗时 ns
+	aiLatencyCount atomic.Int64 // 识别请求次数
+
+	lastFrameRead   atomic.Int64 // 最近一次读到帧 UnixNano
+	lastFramePushed atomic.Int64 // 最近一次推流写帧 UnixNano
+	stallRestarts   atomic.Int64 // 看门狗累计重启次数
+	stallStreak     atomic.Int64 // 看门狗连续重启次数，推流恢复后清零
+}
+
+func (st *sessionStatus) reset() {
+	st.state.Store(int32(SessionStatePreparing))
+	st.reconnectCount.Store(0)
+	st.startedAt.Store(0)
+	st.stoppedAt.Store(0)
+
+	st.errMu.Lock()
+	st.errors = nil
+	st.errMu.Unlock()
+
+	st.inputMeter.reset()
+	st.outputMeter.reset()
+	st.detectMeter.reset()
+	st.aiLatencyTotal.Store(0)
+	st.aiLatencyCount.Store(0)
+	st.lastFrameRead.Store(0)
+	st.lastFramePushed.Store(0)
+	st.stallRestarts.Store(0)
+	st.stallStreak.Store(0)
+}
+
+func (st *sessionStatus) State() SessionState {
+	return SessionState(st.state.Load())
+}
+
+// setState 切换状态，终止状态不可再切换
+func (st *sessionStatus) setState(state SessionState) bool {
+	for {
+		old := st.state.Load()
+		if SessionState(old).IsTerminal() {
+			return false
+		}
+		if st.state.CompareAndSwap(old, int32(state)) {
+			now := time.Now().UnixNano()
+			switch {
+			case state == SessionStateRunning:
+				st.startedAt.CompareAndSwap(0, now)
+			case state.IsTerminal():
+				st.stoppedAt.Store(now)
+			}
+			return true
+		}
+	}
+}
+
+func (st *sessionStatus) recordError(stage string, err error) {
+	if err == nil {
+		return
+	}
+	st.recordErrorCode(stage, "", err.Error())
+}
+
+// recordErrorCode 记录错误，与上一条的阶段和已知错误类型相同时只累加次数，避免重复的错误挤出最初的原因
+func (st *sessionStatus) recordErrorCode(stage, code, message string) {
+	st.errMu.Lock()
+	defer st.errMu.Unlock()
+	now := time.Now()
+	if n := len(st.errors); n > 0 && code != "" && st.errors[n-1].Stage == stage && st.errors[n-1].Code == code {
+		st.errors[n-1].Count++
+		st.errors[n-1].LastSeen = now
+		return
+	}
+	st.errors = append(st.errors, SessionError{Time: now, Stage: stage, Code: code, Message: message, Count: 1, LastSeen: now})
+	if len(st.errors) > maxSessionErrorHistory {
+		st.errors = st.errors[len(st.errors)-maxSessionErrorHistory:]
+	}
+}
+
+// Errors 错误历史副本，按时间先后排序
+func (st *sessionStatus) Errors() []SessionError {
+	st.errMu.RLock()
+	defer st.errMu.RUnlock()
+	return append([]SessionError{}, st.errors...)
+}
+
+func (st *sessionStatus) LastError() *SessionError {
+	st.errMu.RLock()
+	defer st.errMu.RUnlock()
+	if len(st.errors) == 0 {
+		return nil
+	}
+	lastErr := st.errors[len(st.errors)-1]
+	return &lastErr
+}
+
+// Uptime 运行时长，未运行为 0
+func (st *sessionStatus) Uptime() time.Duration {
+	startedAt := st.startedAt.Load()
+	if startedAt == 0 {
+		return 0
+	}
+	end := time.Now().UnixNano()
+	if stoppedAt := st.stoppedAt.Load(); stoppedAt != 0 {
+		end = stoppedAt
+	}
+	return time.Duration(end - startedAt)
+}
+
+func (st *sessionStatus) StoppedAt() time.Time {
+	return unixNanoTime(st.stoppedAt.Load())
+}
+
+// unixNanoTime UnixNano 转换为时间，0 返回零值
+func unixNanoTime(nano int64) time.Time {
+	if nano == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nano)
+}
+
+func (st *sessionStatus) markAILatency(d time.Duration) {
+	st.aiLatencyTotal.Add(int64(d))
+	st.aiLatencyCount.Add(1)
+}
+
+// markFrameRead 读到一帧
+func (st *sessionStatus) markFrameRead() {
+	st.inputMeter.Mark()
+	st.lastFrameRead.Store(time.Now().UnixNano())
+}
+
+// markFramePushed 推流写入一帧，卡流已恢复
+func (st *sessionStatus) markFramePushed() {
+	st.outputMeter.Mark()
+	st.lastFramePushed.Store(time.Now().UnixNano())
+	if st.stallStreak.Load() != 0 {
+		st.stallStreak.Store(0)
+	}
+}
+
+// touchFrames (重新)启动 FFmpeg 后重置帧时间，看门狗从此刻重新计时
+func (st *sessionStatus) touchFrames() {
+	now := time.Now().UnixNano()
+	st.lastFrameRead.Store(now)
+	st.lastFramePushed.Store(now)
+}
+
+func (st *sessionStatus) Stats() SessionStats {
+	stats := SessionStats{
+		InputFPS:  st.inputMeter.Rate(),
+		OutputFPS: st.outputMeter.Rate(),
+		DetectFPS: st.detectMeter.Rate(),
+
+		LastFrameReadAt:   unixNanoTime(st.lastFrameRead.Load()),
+		LastFramePushedAt: unixNanoTime(st.lastFramePushed.Load()),
+	}
+	if count := st.aiLatencyCount.Load(); count > 0 {
+		stats.AvgAILatencyMs = float64(st.aiLatencyTotal.Load()) / float64(count) / float64(time.Millisecond)
+	}
+	return stats
+}
+
+const rateMeterWindow = time.Second // 速率统计窗口
+
+// rateMeter 按固定窗口统计事件速率（次/秒）
+type rateMeter struct {
+	mu          sync.Mutex
+	windowStart time.Time
+	count       int64
+	rate        float64
+}
+
+func (m *rateMeter) reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.windowStart = time.Time{}
+	m.count = 0
+	m.rate = 0
+}
+
+func (m *rateMeter) Mark() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	if m.windowStart.IsZero() {
+		m.windowStart = now
+	}
+	if elapsed := now.Sub(m.windowStart); elapsed >= rateMeterWindow {
+		m.rate = float64(m.count) / elapsed.Seconds()
+		m.count = 0
+		m.windowStart = now
+	}
+	m.count++
+}
+
+func (m *rateMeter) Rate() float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.windowStart.IsZero() {
+		return 0
+	}
+	// 超过两个窗口没有新事件，速率随时间衰减
+	if elapsed := time.Since(m.windowStart); elapsed >= 2*rateMeterWindow {
+		return float64(m.count) / elapsed.Seconds()
+	}
+	return m.rate
+}
@@ -0,0 +1,116 @@
+package engine
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSessionStateTransitions(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    []SessionState // 依次切换的状态
+		want    SessionState
+		ok      bool // 最后一次切换是否成功
+		started bool
+		stopped bool
+	}{
+		{"preparing to running", []SessionState{SessionStateRunning}, SessionStateRunning, true, true, false},
+		{"running to reconnecting", []SessionState{SessionStateRunning, SessionStateReconnecting}, SessionStateReconnecting, true, true, false},
+		{"reconnecting back to running", []SessionState{SessionStateRunning, SessionStateReconnecting, SessionStateRunning}, SessionStateRunning, true, true, false},
+		{"preparing to failed", []SessionState{SessionStateFailed}, SessionStateFailed, true, false, true},
+		{"running to stopped", []SessionState{SessionStateRunning, SessionStateStopped}, SessionStateStopped, true, true, true},
+		{"failed is terminal", []SessionState{SessionStateFailed, SessionStateRunning}, SessionStateFailed, false, false, true},
+		{"stopped is terminal", []SessionState{SessionStateRunning, SessionStateStopped, SessionStateFailed}, SessionStateStopped, false, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var st sessionStatus
+			st.reset()
+			var ok bool
+			for _, state := range tt.path {
+				ok = st.setState(state)
+			}
+			if st.State() != tt.want || ok != tt.ok {
+				t.Fatalf("state = %s ok = %v, want %s %v", st.State(), ok, tt.want, tt.ok)
+			}
+			if started := st.startedAt.Load() != 0; started != tt.started {
+				t.Fatalf("started = %v, want %v", started, tt.started)
+			}
+			if stopped := !st.StoppedAt().IsZero(); stopped != tt.stopped {
+				t.Fatalf("stopped = %v, want %v", stopped, tt.stopped)
+			}
+		})
+	}
+}
+
+func TestSessionErrorHistoryCapped(t *testing.T) {
+	tests := []struct {
+		name  string
+		count int
+		first string // 保留的最早一条
+	}{
+		{"empty", 0, ""},
+		{"below cap", 3, "err 0"},
+		{"at cap", maxSessionErrorHistory, "err 0"},
+		{"over cap drops oldest", maxSessionErrorHistory + 5, "err 5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var st sessionStatus
+			st.recordError("pull", nil) // nil 不记录
+			for i := 0; i < tt.count; i++ {
+				st.recordError("pull", fmt.Errorf("err %d", i))
+			}
+			errs := st.Errors()
+			if len(errs) != min(tt.count, maxSessionErrorHistory) {
+				t.Fatalf("len(errors) = %d", len(errs))
+			}
+			if tt.count == 0 {
+				if st.LastError() != nil {
+					t.Fatal("want no last error")
+				}
+				return
+			}
+			if errs[0].Message != tt.first {
+				t.Fatalf("first error = %q, want %q", errs[0].Message, tt.first)
+			}
+			if last := st.LastError(); last.Message != fmt.Sprintf("err %d", tt.count-1) {
+				t.Fatalf("last error = %q", last.Message)
+			}
+		})
+	}
+}
+
+func TestSessionErrorHistoryMergesRepeatedCode(t *testing.T) {
+	var st sessionStatus
+	st.recordErrorCode("pull", FFmpegErrUnauthorized, "401 Unauthorized (first)")
+	for i := 0; i < maxSessionErrorHistory*2; i++ {
+		st.recordErrorCode("pull", FFmpegErrTimeout, fmt.Sprintf("Connection timed out %d", i))
+	}
+	st.recordErrorCode("push", FFmpegErrTimeout, "Connection timed out") // 阶段不同不合并
+	st.recordError("pull", fmt.Errorf("exit status 1"))                  // 未知错误不合并
+	st.recordError("pull", fmt.Errorf("exit status 1"))
+
+	errs := st.Errors()
+	want := []struct {
+		stage, code string
+		count       int
+	}{
+		{"pull", FFmpegErrUnauthorized, 1},
+		{"pull", FFmpegErrTimeout, maxSessionErrorHistory * 2},
+		{"push", FFmpegErrTimeout, 1},
+		{"pull", "", 1},
+		{"pull", "", 1},
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("errors = %+v", errs)
+	}
+	for i := range want {
+		if errs[i].Stage != want[i].stage || errs[i].Code != want[i].code || errs[i].Count != want[i].count {
+			t.Fatalf("errors[%d] = %+v, want %+v", i, errs[i], want[i])
+		}
+	}
+	if errs[1].Message != "Connection timed out 0" || errs[1].LastSeen.Before(errs[1].Time) {
+		t.Fatalf("merged error should keep the first message: %+v", errs[1])
+	}
+}
@@ -0,0 +1,101 @@
+package engine
+
+import (
+	"fmt"
+	"go.uber.org/zap"
+	"go_client/config"
+	"time"
+)
+
+const watchdogInterval = time.Second // 看门狗检查间隔
+
+// watchdogLoop 卡流看门狗：FFmpeg 未退出（没有 EOF）但长时间没有推流帧时重启卡住的 FFmpeg，连续重启超过上限后会话失败
+func (s *SessionManager) watchdogLoop() {
+	s.logger.Info("session manager watchdog running...")
+	ticker := time.NewTicker(watchdogInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			s.logger.Info("🛑 SessionManager 卡流看门狗关闭")
+			return
+		case now := <-ticker.C:
+			watchdog := s.Config().Engine.Watchdog
+			if watchdog.StallTimeout <= 0 {
+				continue
+			}
+			s.sessions.Range(func(_ string, _session *Session) bool {
+				// 重连中由拉流重连逻辑处理
+				if _session.runningStatus.Load() && _session.State() == SessionStateRunning {
+					_session.checkStall(watchdog, now)
+				}
+				return true
+			})
+		}
+	}
+}
+
+// stalledStage 判断卡住的阶段：超过 timeout 没有推流帧时，读到的帧比推流帧新说明卡在推流写入，否则卡在拉流读帧
+func (st *sessionStatus) stalledStage(timeout time.Duration, now time.Time) (stage string, idle time.Duration) {
+	lastPushed := st.lastFramePushed.Load()
+	if lastPushed == 0 {
+		return "", 0
+	}
+	idle = now.Sub(time.Unix(0, lastPushed))
+	if idle < timeout {
+		return "", 0
+	}
+	if st.lastFrameRead.Load() > lastPushed {
+		return "push", idle
+	}
+	return "pull", idle
+}
+
+// checkStall 检查会话是否卡流，卡流时重启对应 FFmpeg 或使会话失败，原因记入错误历史
+func (s *Session) checkStall(watchdog config.Watchdog, now time.Time) {
+	stage, idle := s.status.stalledStage(time.Duration(watchdog.StallTimeout)*time.Second, now)
+	if stage == "" {
+		return
+	}
+
+	reason := fmt.Sprintf("%s stalled: no frame pushed for %s", stage, idle.Truncate(time.Second))
+	if streak := s.status.stallStreak.Load(); streak >= int64(watchdog.MaxRestarts) {
+		s.logger.Error("🐕 卡流无法恢复，会话失败", zap.String("stage", stage), zap.Duration("idle", idle), zap.Int64("restarts", streak))
+		s.fail("watchdog", fmt.Errorf("%s, %d restarts did not recover", reason, streak))
+		s.runningStatus.Store(false)
+		s.cancelFunc()
+		// 结束卡住的 FFmpeg，使阻塞在读写管道的 Run 退出
+		s.pipeMu.Lock()
+		s.stopPuller()
+		s.stopPusher()
+		s.pipeMu.Unlock()
+		return
+	}
+
+	s.logger.Warn("🐕 卡流，重启 FFmpeg", zap.String("stage", stage), zap.Duration("idle", idle))
+	s.status.recordErrorCode("watchdog", "stalled", reason)
+	s.status.stallStreak.Add(1)
+	s.status.stallRestarts.Add(1)
+	s.audit(AuditRestarted, reason)
+	if err := s.restartStage(stage); err != nil {
+		s.logger.Error("🐕 重启 FFmpeg 失败", zap.String("stage", stage), zap.Error(err))
+		s.fail("watchdog", err)
+		s.runningStatus.Store(false)
+		s.cancelFunc()
+	}
+}
+
+// restartStage 重启拉流或推流 FFmpeg，streamKey 保持不变
+func (s *Session) restartStage(stage string) error {
+	s.pipeMu.Lock()
+	defer s.pipeMu.Unlock()
+	// 先递增管道代数，Run 中因旧管道关闭产生的读写错误不再视为流断开
+	s.pipeGen.Add(1)
+	defer s.status.touchFrames()
+	if stage == "pull" {
+		s.stopPuller()
+		return s.startPuller()
+	}
+	s.stopPusher()
+	return s.startPusher()
+}
@@ -0,0 +1,35 @@
+package engine
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStalledStage(t *testing.T) {
+	now := time.Now()
+	timeout := 10 * time.Second
+	tests := []struct {
+		name       string
+		read, push time.Duration // 距 now 的时间
+		want       string
+	}{
+		{name: "healthy", read: time.Second, push: time.Second, want: ""},
+		{name: "pull stalled", read: 20 * time.Second, push: 20 * time.Second, want: "pull"},
+		{name: "push stalled", read: 15 * time.Second, push: 20 * time.Second, want: "push"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var st sessionStatus
+			st.lastFrameRead.Store(now.Add(-tt.read).UnixNano())
+			st.lastFramePushed.Store(now.Add(-tt.push).UnixNano())
+			if stage, _ := st.stalledStage(timeout, now); stage != tt.want {
+				t.Fatalf("stalledStage() = %q, want %q", stage, tt.want)
+			}
+		})
+	}
+
+	var st sessionStatus
+	if stage, _ := st.stalledStage(timeout, now); stage != "" {
+		t.Fatalf("not started session should not be stalled, got %q", stage)
+	}
+}
@@ -0,0 +1,63 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go_client/config"
+	"go_client/pkg/tracing"
+	"os"
+)
+
+// setupTracing 按配置初始化链路追踪，返回关闭函数用于刷新剩余 span；未开启时仅设置传播器
+func setupTracing(ctx context.Context, cfg config.Tracing) (func(context.Context) error, error) {
+	if !cfg.Enable {
+		tracing.SetupPropagator()
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, closeExporter, err := newTraceExporter(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	shutdown, err := tracing.Setup(exporter,
+		tracing.WithServiceName(cfg.Service()),
+		tracing.WithSampleRatio(cfg.SampleRatio))
+	if err != nil {
+		closeExporter()
+		return nil, err
+	}
+	return func(ctx context.Context) error {
+		defer closeExporter()
+		return shutdown(ctx)
+	}, nil
+}
+
+// newTraceExporter 按导出方式创建 span 导出器，返回的关闭函数用于关闭 file 导出的文件
+func newTraceExporter(ctx context.Context, cfg config.Tracing) (sdktrace.SpanExporter, func(), error) {
+	noop := func() {}
+	switch cfg.Exporter {
+	case config.TracingExporterOTLPGRPC, "":
+		exporter, err := tracing.NewOTLPGRPCExporter(ctx, cfg.Endpoint, cfg.Insecure)
+		return exporter, noop, err
+	case config.TracingExporterOTLPHTTP:
+		exporter, err := tracing.NewOTLPHTTPExporter(ctx, cfg.Endpoint, cfg.Insecure)
+		return exporter, noop, err
+	case config.TracingExporterStdout:
+		exporter, err := tracing.NewWriterExporter(os.Stdout)
+		return exporter, noop, err
+	case config.TracingExporterFile:
+		f, err := os.OpenFile(cfg.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, nil, fmt.Errorf("open tracing file: %w", err)
+		}
+		exporter, err := tracing.NewWriterExporter(f)
+		if err != nil {
+			_ = f.Close()
+			return nil, nil, err
+		}
+		return exporter, func() { _ = f.Close() }, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported tracing exporter %q", cfg.Exporter)
+	}
+}
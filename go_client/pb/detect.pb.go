@@ -25,12 +25,20 @@ type CreateSessionReq struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id         string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	RtspURL    string `protobuf:"bytes,2,opt,name=rtspURL,proto3" json:"rtspURL,omitempty"`
-	Width      int32  `protobuf:"varint,3,opt,name=width,proto3" json:"width,omitempty"`
-	Height     int32  `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
-	Framerate  int32  `protobuf:"varint,5,opt,name=framerate,proto3" json:"framerate,omitempty"`
-	RetryTimes int32  `protobuf:"varint,6,opt,name=retryTimes,proto3" json:"retryTimes,omitempty"`
+	Id              string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RtspURL         string            `protobuf:"bytes,2,opt,name=rtspURL,proto3" json:"rtspURL,omitempty"`
+	Width           int32             `protobuf:"varint,3,opt,name=width,proto3" json:"width,omitempty"`         // 为 0 使用默认值 1280
+	Height          int32             `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`       // 为 0 使用默认值 720
+	Framerate       int32             `protobuf:"varint,5,opt,name=framerate,proto3" json:"framerate,omitempty"` // 为 0 使用默认值 25
+	RetryTimes      int32             `protobuf:"varint,6,opt,name=retryTimes,proto3" json:"retryTimes,omitempty"`
+	EncodingProfile string            `protobuf:"bytes,7,opt,name=encodingProfile,proto3" json:"encodingProfile,omitempty"`                                                                       // 命名编码配置，为空使用默认编码配置
+	Encoding        *EncodingProfile  `protobuf:"bytes,8,opt,name=encoding,proto3" json:"encoding,omitempty"`                                                                                     // 编码配置覆盖项，零值字段沿用基础配置
+	Labels          map[string]string `protobuf:"bytes,9,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // 标签，可用于列表过滤
+	Name            string            `protobuf:"bytes,10,opt,name=name,proto3" json:"name,omitempty"`                                                                                            // 展示名称
+	Site            string            `protobuf:"bytes,11,opt,name=site,proto3" json:"site,omitempty"`                                                                                            // 站点/位置，可用于列表过滤
+	Metadata        string            `protobuf:"bytes,12,opt,name=metadata,proto3" json:"metadata,omitempty"`                                                                                    // 自定义元数据 JSON，随识别事件下发
+	Schedule        *DetectSchedule   `protobuf:"bytes,13,opt,name=schedule,proto3" json:"schedule,omitempty"`                                                                                    // 识别时间计划，为空表示不按计划切换识别状态
+	Counting        *LineCounting     `protobuf:"bytes,14,opt,name=counting,proto3" json:"counting,omitempty"`                                                                                    // 虚拟线进出计数，为空表示不计数
 }
 
 func (x *CreateSessionReq) Reset() {
@@ -107,16 +115,75 @@ func (x *CreateSessionReq) GetRetryTimes() int32 {
 	return 0
 }
 
-type SessionIDReq struct {
+func (x *CreateSessionReq) GetEncodingProfile() string {
+	if x != nil {
+		return x.EncodingProfile
+	}
+	return ""
+}
+
+func (x *CreateSessionReq) GetEncoding() *EncodingProfile {
+	if x != nil {
+		return x.Encoding
+	}
+	return nil
+}
+
+func (x *CreateSessionReq) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *CreateSessionReq) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateSessionReq) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+func (x *CreateSessionReq) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+func (x *CreateSessionReq) GetSchedule() *DetectSchedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+func (x *CreateSessionReq) GetCounting() *LineCounting {
+	if x != nil {
+		return x.Counting
+	}
+	return nil
+}
+
+// 虚拟线计数：跟踪识别目标中心点，按类别统计穿越每条线的进出次数
+type LineCounting struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SessionID string `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"`
+	Lines    []*CountingLine `protobuf:"bytes,1,rep,name=lines,proto3" json:"lines,omitempty"`
+	Reset_   string          `protobuf:"bytes,2,opt,name=reset,proto3" json:"reset,omitempty"`       // 计数清零 cron 表达式，如 @daily，为空不自动清零
+	Timezone string          `protobuf:"bytes,3,opt,name=timezone,proto3" json:"timezone,omitempty"` // reset 使用的 IANA 时区
 }
 
-func (x *SessionIDReq) Reset() {
-	*x = SessionIDReq{}
+func (x *LineCounting) Reset() {
+	*x = LineCounting{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_detect_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -124,13 +191,13 @@ func (x *SessionIDReq) Reset() {
 	}
 }
 
-func (x *SessionIDReq) String() string {
+func (x *LineCounting) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SessionIDReq) ProtoMessage() {}
+func (*LineCounting) ProtoMessage() {}
 
-func (x *SessionIDReq) ProtoReflect() protoreflect.Message {
+func (x *LineCounting) ProtoReflect() protoreflect.Message {
 	mi := &file_detect_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -142,31 +209,48 @@ func (x *SessionIDReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SessionIDReq.ProtoReflect.Descriptor instead.
-func (*SessionIDReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use LineCounting.ProtoReflect.Descriptor instead.
+func (*LineCounting) Descriptor() ([]byte, []int) {
 	return file_detect_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *SessionIDReq) GetSessionID() string {
+func (x *LineCounting) GetLines() []*CountingLine {
 	if x != nil {
-		return x.SessionID
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *LineCounting) GetReset_() string {
+	if x != nil {
+		return x.Reset_
 	}
 	return ""
 }
 
-type SessionDesc struct {
+func (x *LineCounting) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+// 坐标为识别分辨率下的像素坐标；沿 (x1,y1)->(x2,y2) 方向看，从左侧穿越到右侧为 in，反之为 out
+type CountingLine struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	StreamKey     string `protobuf:"bytes,2,opt,name=streamKey,proto3" json:"streamKey,omitempty"`
-	PushUrlPublic string `protobuf:"bytes,3,opt,name=pushUrlPublic,proto3" json:"pushUrlPublic,omitempty"`
-	DetectStatus  bool   `protobuf:"varint,4,opt,name=detectStatus,proto3" json:"detectStatus,omitempty"` // 识别状态 false 停止 true 识别
+	Name   string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	X1     int32    `protobuf:"varint,2,opt,name=x1,proto3" json:"x1,omitempty"`
+	Y1     int32    `protobuf:"varint,3,opt,name=y1,proto3" json:"y1,omitempty"`
+	X2     int32    `protobuf:"varint,4,opt,name=x2,proto3" json:"x2,omitempty"`
+	Y2     int32    `protobuf:"varint,5,opt,name=y2,proto3" json:"y2,omitempty"`
+	Labels []string `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty"` // 计数的识别类别，为空表示全部
 }
 
-func (x *SessionDesc) Reset() {
-	*x = SessionDesc{}
+func (x *CountingLine) Reset() {
+	*x = CountingLine{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_detect_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -174,13 +258,13 @@ func (x *SessionDesc) Reset() {
 	}
 }
 
-func (x *SessionDesc) String() string {
+func (x *CountingLine) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SessionDesc) ProtoMessage() {}
+func (*CountingLine) ProtoMessage() {}
 
-func (x *SessionDesc) ProtoReflect() protoreflect.Message {
+func (x *CountingLine) ProtoReflect() protoreflect.Message {
 	mi := &file_detect_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -192,50 +276,64 @@ func (x *SessionDesc) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SessionDesc.ProtoReflect.Descriptor instead.
-func (*SessionDesc) Descriptor() ([]byte, []int) {
+// Deprecated: Use CountingLine.ProtoReflect.Descriptor instead.
+func (*CountingLine) Descriptor() ([]byte, []int) {
 	return file_detect_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *SessionDesc) GetId() string {
+func (x *CountingLine) GetName() string {
 	if x != nil {
-		return x.Id
+		return x.Name
 	}
 	return ""
 }
 
-func (x *SessionDesc) GetStreamKey() string {
+func (x *CountingLine) GetX1() int32 {
 	if x != nil {
-		return x.StreamKey
+		return x.X1
 	}
-	return ""
+	return 0
 }
 
-func (x *SessionDesc) GetPushUrlPublic() string {
+func (x *CountingLine) GetY1() int32 {
 	if x != nil {
-		return x.PushUrlPublic
+		return x.Y1
 	}
-	return ""
+	return 0
 }
 
-func (x *SessionDesc) GetDetectStatus() bool {
+func (x *CountingLine) GetX2() int32 {
 	if x != nil {
-		return x.DetectStatus
+		return x.X2
 	}
-	return false
+	return 0
 }
 
-type GetSessionDescByIDResp struct {
+func (x *CountingLine) GetY2() int32 {
+	if x != nil {
+		return x.Y2
+	}
+	return 0
+}
+
+func (x *CountingLine) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type CrossCount struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Exists  bool         `protobuf:"varint,1,opt,name=exists,proto3" json:"exists,omitempty"` // 是否存在
-	Session *SessionDesc `protobuf:"bytes,2,opt,name=session,proto3" json:"session,omitempty"`
+	In  int64 `protobuf:"varint,1,opt,name=in,proto3" json:"in,omitempty"`
+	Out int64 `protobuf:"varint,2,opt,name=out,proto3" json:"out,omitempty"`
 }
 
-func (x *GetSessionDescByIDResp) Reset() {
-	*x = GetSessionDescByIDResp{}
+func (x *CrossCount) Reset() {
+	*x = CrossCount{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_detect_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -243,13 +341,13 @@ func (x *GetSessionDescByIDResp) Reset() {
 	}
 }
 
-func (x *GetSessionDescByIDResp) String() string {
+func (x *CrossCount) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSessionDescByIDResp) ProtoMessage() {}
+func (*CrossCount) ProtoMessage() {}
 
-func (x *GetSessionDescByIDResp) ProtoReflect() protoreflect.Message {
+func (x *CrossCount) ProtoReflect() protoreflect.Message {
 	mi := &file_detect_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -261,35 +359,38 @@ func (x *GetSessionDescByIDResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSessionDescByIDResp.ProtoReflect.Descriptor instead.
-func (*GetSessionDescByIDResp) Descriptor() ([]byte, []int) {
+// Deprecated: Use CrossCount.ProtoReflect.Descriptor instead.
+func (*CrossCount) Descriptor() ([]byte, []int) {
 	return file_detect_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *GetSessionDescByIDResp) GetExists() bool {
+func (x *CrossCount) GetIn() int64 {
 	if x != nil {
-		return x.Exists
+		return x.In
 	}
-	return false
+	return 0
 }
 
-func (x *GetSessionDescByIDResp) GetSession() *SessionDesc {
+func (x *CrossCount) GetOut() int64 {
 	if x != nil {
-		return x.Session
+		return x.Out
 	}
-	return nil
+	return 0
 }
 
-type AllSessionDescResp struct {
+type LineCount struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Sessions []*SessionDesc `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	Name   string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	In     int64                  `protobuf:"varint,2,opt,name=in,proto3" json:"in,omitempty"` // 全部类别合计
+	Out    int64                  `protobuf:"varint,3,opt,name=out,proto3" json:"out,omitempty"`
+	Labels map[string]*CrossCount `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // 按类别计数
 }
 
-func (x *AllSessionDescResp) Reset() {
-	*x = AllSessionDescResp{}
+func (x *LineCount) Reset() {
+	*x = LineCount{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_detect_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -297,13 +398,13 @@ func (x *AllSessionDescResp) Reset() {
 	}
 }
 
-func (x *AllSessionDescResp) String() string {
+func (x *LineCount) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AllSessionDescResp) ProtoMessage() {}
+func (*LineCount) ProtoMessage() {}
 
-func (x *AllSessionDescResp) ProtoReflect() protoreflect.Message {
+func (x *LineCount) ProtoReflect() protoreflect.Message {
 	mi := &file_detect_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -315,28 +416,51 @@ func (x *AllSessionDescResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AllSessionDescResp.ProtoReflect.Descriptor instead.
-func (*AllSessionDescResp) Descriptor() ([]byte, []int) {
+// Deprecated: Use LineCount.ProtoReflect.Descriptor instead.
+func (*LineCount) Descriptor() ([]byte, []int) {
 	return file_detect_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *AllSessionDescResp) GetSessions() []*SessionDesc {
+func (x *LineCount) GetName() string {
 	if x != nil {
-		return x.Sessions
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LineCount) GetIn() int64 {
+	if x != nil {
+		return x.In
+	}
+	return 0
+}
+
+func (x *LineCount) GetOut() int64 {
+	if x != nil {
+		return x.Out
+	}
+	return 0
+}
+
+func (x *LineCount) GetLabels() map[string]*CrossCount {
+	if x != nil {
+		return x.Labels
 	}
 	return nil
 }
 
-type GenericResp struct {
+type LineCountsResp struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ok bool `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Since     int64        `protobuf:"varint,1,opt,name=since,proto3" json:"since,omitempty"`         // 本轮计数开始时间 Unix 毫秒
+	NextReset int64        `protobuf:"varint,2,opt,name=nextReset,proto3" json:"nextReset,omitempty"` // 下一次自动清零时间 Unix 毫秒，0 表示不自动清零
+	Lines     []*LineCount `protobuf:"bytes,3,rep,name=lines,proto3" json:"lines,omitempty"`
 }
 
-func (x *GenericResp) Reset() {
-	*x = GenericResp{}
+func (x *LineCountsResp) Reset() {
+	*x = LineCountsResp{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_detect_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -344,13 +468,13 @@ func (x *GenericResp) Reset() {
 	}
 }
 
-func (x *GenericResp) String() string {
+func (x *LineCountsResp) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GenericResp) ProtoMessage() {}
+func (*LineCountsResp) ProtoMessage() {}
 
-func (x *GenericResp) ProtoReflect() protoreflect.Message {
+func (x *LineCountsResp) ProtoReflect() protoreflect.Message {
 	mi := &file_detect_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -362,26 +486,46 @@ func (x *GenericResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GenericResp.ProtoReflect.Descriptor instead.
-func (*GenericResp) Descriptor() ([]byte, []int) {
+// Deprecated: Use LineCountsResp.ProtoReflect.Descriptor instead.
+func (*LineCountsResp) Descriptor() ([]byte, []int) {
 	return file_detect_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *GenericResp) GetOk() bool {
+func (x *LineCountsResp) GetSince() int64 {
 	if x != nil {
-		return x.Ok
+		return x.Since
 	}
-	return false
+	return 0
 }
 
-type Empty struct {
+func (x *LineCountsResp) GetNextReset() int64 {
+	if x != nil {
+		return x.NextReset
+	}
+	return 0
+}
+
+func (x *LineCountsResp) GetLines() []*LineCount {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+// 识别时间计划：每周时间表 windows 或 cron 表达式 start/stop 二选一
+type DetectSchedule struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Timezone string            `protobuf:"bytes,1,opt,name=timezone,proto3" json:"timezone,omitempty"` // IANA 时区，默认服务器本地时区
+	Windows  []*ScheduleWindow `protobuf:"bytes,2,rep,name=windows,proto3" json:"windows,omitempty"`
+	Start    string            `protobuf:"bytes,3,opt,name=start,proto3" json:"start,omitempty"` // cron 表达式，到点开启识别
+	Stop     string            `protobuf:"bytes,4,opt,name=stop,proto3" json:"stop,omitempty"`   // cron 表达式，到点暂停识别
 }
 
-func (x *Empty) Reset() {
-	*x = Empty{}
+func (x *DetectSchedule) Reset() {
+	*x = DetectSchedule{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_detect_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -389,13 +533,13 @@ func (x *Empty) Reset() {
 	}
 }
 
-func (x *Empty) String() string {
+func (x *DetectSchedule) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Empty) ProtoMessage() {}
+func (*DetectSchedule) ProtoMessage() {}
 
-func (x *Empty) ProtoReflect() protoreflect.Message {
+func (x *DetectSchedule) ProtoReflect() protoreflect.Message {
 	mi := &file_detect_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -407,150 +551,3288 @@ func (x *Empty) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
-func (*Empty) Descriptor() ([]byte, []int) {
+// Deprecated: Use DetectSchedule.ProtoReflect.Descriptor instead.
+func (*DetectSchedule) Descriptor() ([]byte, []int) {
 	return file_detect_proto_rawDescGZIP(), []int{6}
 }
 
-var File_detect_proto protoreflect.FileDescriptor
-
-var file_detect_proto_rawDesc = []byte{
-	0x0a, 0x0c, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02,
-	0x70, 0x62, 0x22, 0xa8, 0x01, 0x0a, 0x10, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x74, 0x73, 0x70, 0x55,
-	0x52, 0x4c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x74, 0x73, 0x70, 0x55, 0x52,
-	0x4c, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68,
-	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12,
-	0x1c, 0x0a, 0x09, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x09, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x0a,
-	0x0a, 0x72, 0x65, 0x74, 0x72, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x0a, 0x72, 0x65, 0x74, 0x72, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x22, 0x2c, 0x0a,
-	0x0c, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x52, 0x65, 0x71, 0x12, 0x1c, 0x0a,
-	0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x22, 0x85, 0x01, 0x0a, 0x0b,
-	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x12, 0x0e, 0x0a, 0x02, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73,
-	0x74, 0x72, 0x65, 0x61, 0x6d, 0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
-	0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4b, 0x65, 0x79, 0x12, 0x24, 0x0a, 0x0d, 0x70, 0x75, 0x73,
-	0x68, 0x55, 0x72, 0x6c, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0d, 0x70, 0x75, 0x73, 0x68, 0x55, 0x72, 0x6c, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x12,
-	0x22, 0x0a, 0x0c, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x22, 0x5b, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
-	0x6e, 0x44, 0x65, 0x73, 0x63, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70, 0x12, 0x16, 0x0a,
-	0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65,
-	0x78, 0x69, 0x73, 0x74, 0x73, 0x12, 0x29, 0x0a, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x73, 0x73,
-	0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x52, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
-	0x22, 0x41, 0x0a, 0x12, 0x41, 0x6c, 0x6c, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x65,
-	0x73, 0x63, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2b, 0x0a, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
-	0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x65,
-	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x52, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69,
-	0x6f, 0x6e, 0x73, 0x22, 0x1d, 0x0a, 0x0b, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x69, 0x63, 0x52, 0x65,
-	0x73, 0x70, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02,
-	0x6f, 0x6b, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x32, 0xdd, 0x02, 0x0a, 0x0d,
-	0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x36, 0x0a,
-	0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14,
-	0x2e, 0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
-	0x6e, 0x52, 0x65, 0x71, 0x1a, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
-	0x6e, 0x44, 0x65, 0x73, 0x63, 0x12, 0x36, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x53,
-	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x12, 0x09, 0x2e, 0x70, 0x62, 0x2e,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x6c, 0x6c, 0x53, 0x65,
-	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x52, 0x65, 0x73, 0x70, 0x12, 0x42, 0x0a,
-	0x12, 0x47, 0x65, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x42,
-	0x79, 0x49, 0x44, 0x12, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
-	0x49, 0x44, 0x52, 0x65, 0x71, 0x1a, 0x1a, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x65,
-	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x73,
-	0x70, 0x12, 0x2f, 0x0a, 0x0a, 0x53, 0x74, 0x6f, 0x70, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x12,
-	0x10, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x52, 0x65,
-	0x71, 0x1a, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x69, 0x63, 0x52, 0x65,
-	0x73, 0x70, 0x12, 0x33, 0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x65, 0x44, 0x65,
-	0x74, 0x65, 0x63, 0x74, 0x12, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
-	0x6e, 0x49, 0x44, 0x52, 0x65, 0x71, 0x1a, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x6e, 0x65,
-	0x72, 0x69, 0x63, 0x52, 0x65, 0x73, 0x70, 0x12, 0x32, 0x0a, 0x0d, 0x52, 0x65, 0x6d, 0x6f, 0x76,
-	0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x65,
-	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x52, 0x65, 0x71, 0x1a, 0x0f, 0x2e, 0x70, 0x62, 0x2e,
-	0x47, 0x65, 0x6e, 0x65, 0x72, 0x69, 0x63, 0x52, 0x65, 0x73, 0x70, 0x42, 0x07, 0x5a, 0x05, 0x2e,
-	0x2f, 0x3b, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *DetectSchedule) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
 }
 
-var (
-	file_detect_proto_rawDescOnce sync.Once
-	file_detect_proto_rawDescData = file_detect_proto_rawDesc
-)
+func (x *DetectSchedule) GetWindows() []*ScheduleWindow {
+	if x != nil {
+		return x.Windows
+	}
+	return nil
+}
 
-func file_detect_proto_rawDescGZIP() []byte {
-	file_detect_proto_rawDescOnce.Do(func() {
-		file_detect_proto_rawDescData = protoimpl.X.CompressGZIP(file_detect_proto_rawDescData)
-	})
-	return file_detect_proto_rawDescData
+func (x *DetectSchedule) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
 }
 
-var file_detect_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
-var file_detect_proto_goTypes = []any{
-	(*CreateSessionReq)(nil),       // 0: pb.CreateSessionReq
-	(*SessionIDReq)(nil),           // 1: pb.SessionIDReq
-	(*SessionDesc)(nil),            // 2: pb.SessionDesc
-	(*GetSessionDescByIDResp)(nil), // 3: pb.GetSessionDescByIDResp
-	(*AllSessionDescResp)(nil),     // 4: pb.AllSessionDescResp
-	(*GenericResp)(nil),            // 5: pb.GenericResp
-	(*Empty)(nil),                  // 6: pb.Empty
+func (x *DetectSchedule) GetStop() string {
+	if x != nil {
+		return x.Stop
+	}
+	return ""
 }
-var file_detect_proto_depIdxs = []int32{
-	2, // 0: pb.GetSessionDescByIDResp.session:type_name -> pb.SessionDesc
-	2, // 1: pb.AllSessionDescResp.sessions:type_name -> pb.SessionDesc
-	0, // 2: pb.DetectService.CreateSession:input_type -> pb.CreateSessionReq
-	6, // 3: pb.DetectService.GetAllSessionDesc:input_type -> pb.Empty
-	1, // 4: pb.DetectService.GetSessionDescByID:input_type -> pb.SessionIDReq
-	1, // 5: pb.DetectService.StopDetect:input_type -> pb.SessionIDReq
-	1, // 6: pb.DetectService.ContinueDetect:input_type -> pb.SessionIDReq
-	1, // 7: pb.DetectService.RemoveSession:input_type -> pb.SessionIDReq
-	2, // 8: pb.DetectService.CreateSession:output_type -> pb.SessionDesc
-	4, // 9: pb.DetectService.GetAllSessionDesc:output_type -> pb.AllSessionDescResp
-	3, // 10: pb.DetectService.GetSessionDescByID:output_type -> pb.GetSessionDescByIDResp
-	5, // 11: pb.DetectService.StopDetect:output_type -> pb.GenericResp
-	5, // 12: pb.DetectService.ContinueDetect:output_type -> pb.GenericResp
-	5, // 13: pb.DetectService.RemoveSession:output_type -> pb.GenericResp
-	8, // [8:14] is the sub-list for method output_type
-	2, // [2:8] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+
+type ScheduleWindow struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Days  []string `protobuf:"bytes,1,rep,name=days,proto3" json:"days,omitempty"`   // mon tue wed thu fri sat sun，为空表示每天
+	Start string   `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"` // HH:MM
+	End   string   `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`     // HH:MM，早于 start 表示跨夜
 }
 
-func init() { file_detect_proto_init() }
-func file_detect_proto_init() {
-	if File_detect_proto != nil {
-		return
+func (x *ScheduleWindow) Reset() {
+	*x = ScheduleWindow{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_detect_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_detect_proto_msgTypes[0].Exporter = func(v any, i int) any {
-			switch v := v.(*CreateSessionReq); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+}
+
+func (x *ScheduleWindow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleWindow) ProtoMessage() {}
+
+func (x *ScheduleWindow) ProtoReflect() protoreflect.Message {
+	mi := &file_detect_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_detect_proto_msgTypes[1].Exporter = func(v any, i int) any {
-			switch v := v.(*SessionIDReq); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleWindow.ProtoReflect.Descriptor instead.
+func (*ScheduleWindow) Descriptor() ([]byte, []int) {
+	return file_detect_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ScheduleWindow) GetDays() []string {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+func (x *ScheduleWindow) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *ScheduleWindow) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+type ScheduleOverride struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Detect bool  `protobuf:"varint,1,opt,name=detect,proto3" json:"detect,omitempty"`
+	Until  int64 `protobuf:"varint,2,opt,name=until,proto3" json:"until,omitempty"` // Unix 毫秒
+}
+
+func (x *ScheduleOverride) Reset() {
+	*x = ScheduleOverride{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_detect_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScheduleOverride) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleOverride) ProtoMessage() {}
+
+func (x *ScheduleOverride) ProtoReflect() protoreflect.Message {
+	mi := &file_detect_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleOverride.ProtoReflect.Descriptor instead.
+func (*ScheduleOverride) Descriptor() ([]byte, []int) {
+	return file_detect_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ScheduleOverride) GetDetect() bool {
+	if x != nil {
+		return x.Detect
+	}
+	return false
+}
+
+func (x *ScheduleOverride) GetUntil() int64 {
+	if x != nil {
+		return x.Until
+	}
+	return 0
+}
+
+type ScheduleTransition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Time   int64 `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`     // Unix 毫秒
+	Detect bool  `protobuf:"varint,2,opt,name=detect,proto3" json:"detect,omitempty"` // 切换后的识别状态
+}
+
+func (x *ScheduleTransition) Reset() {
+	*x = ScheduleTransition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_detect_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScheduleTransition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleTransition) ProtoMessage() {}
+
+func (x *ScheduleTransition) ProtoReflect() protoreflect.Message {
+	mi := &file_detect_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleTransition.ProtoReflect.Descriptor instead.
+func (*ScheduleTransition) Descriptor() ([]byte, []int) {
+	return file_detect_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ScheduleTransition) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *ScheduleTransition) GetDetect() bool {
+	if x != nil {
+		return x.Detect
+	}
+	return false
+}
+
+type ScheduleOverrideReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionID string `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"`
+	Detect    *bool  `protobuf:"varint,2,opt,name=detect,proto3,oneof" json:"detect,omitempty"` // 未设置表示取消覆盖
+	Until     int64  `protobuf:"varint,3,opt,name=until,proto3" json:"until,omitempty"`         // 覆盖截止时间 Unix 毫秒，0 表示到计划的下一次切换为止
+}
+
+func (x *ScheduleOverrideReq) Reset() {
+	*x = ScheduleOverrideReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_detect_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScheduleOverrideReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleOverrideReq) ProtoMessage() {}
+
+func (x *ScheduleOverrideReq) ProtoReflect() protoreflect.Message {
+	mi := &file_detect_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleOverrideReq.ProtoReflect.Descriptor instead.
+func (*ScheduleOverrideReq) Descriptor() ([]byte, []int) {
+	return file_detect_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ScheduleOverrideReq) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+func (x *ScheduleOverrideReq) GetDetect() bool {
+	if x != nil && x.Detect != nil {
+		return *x.Detect
+	}
+	return false
+}
+
+func (x *ScheduleOverrideReq) GetUntil() int64 {
+	if x != nil {
+		return x.Until
+	}
+	return 0
+}
+
+// 推流编码配置，零值字段表示使用默认值
+type EncodingProfile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Codec        string   `protobuf:"bytes,1,opt,name=codec,proto3" json:"codec,omitempty"` // libx264 libx265 libvpx mjpeg
+	Preset       string   `protobuf:"bytes,2,opt,name=preset,proto3" json:"preset,omitempty"`
+	Tune         string   `protobuf:"bytes,3,opt,name=tune,proto3" json:"tune,omitempty"`        // 如 zerolatency
+	Bitrate      int32    `protobuf:"varint,4,opt,name=bitrate,proto3" json:"bitrate,omitempty"` // kbps，设置后优先于 crf
+	Crf          int32    `protobuf:"varint,5,opt,name=crf,proto3" json:"crf,omitempty"`
+	Gop          int32    `protobuf:"varint,6,opt,name=gop,proto3" json:"gop,omitempty"`
+	OutputWidth  int32    `protobuf:"varint,7,opt,name=outputWidth,proto3" json:"outputWidth,omitempty"` // 推流输出分辨率，0 表示与识别分辨率一致
+	OutputHeight int32    `protobuf:"varint,8,opt,name=outputHeight,proto3" json:"outputHeight,omitempty"`
+	Format       string   `protobuf:"bytes,9,opt,name=format,proto3" json:"format,omitempty"` // 输出封装格式，默认 flv
+	Clear        []string `protobuf:"bytes,10,rep,name=clear,proto3" json:"clear,omitempty"`  // 合并时恢复为 FFmpeg 默认值的字段，取值为 toml 键名
+}
+
+func (x *EncodingProfile) Reset() {
+	*x = EncodingProfile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_detect_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EncodingProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EncodingProfile) ProtoMessage() {}
+
+func (x *EncodingProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_detect_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EncodingProfile.ProtoReflect.Descriptor instead.
+func (*EncodingProfile) Descriptor() ([]byte, []int) {
+	return file_detect_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *EncodingProfile) GetCodec() string {
+	if x != nil {
+		return x.Codec
+	}
+	return ""
+}
+
+func (x *EncodingProfile) GetPreset() string {
+	if x != nil {
+		return x.Preset
+	}
+	return ""
+}
+
+func (x *EncodingProfile) GetTune() string {
+	if x != nil {
+		return x.Tune
+	}
+	return ""
+}
+
+func (x *EncodingProfile) GetBitrate() int32 {
+	if x != nil {
+		return x.Bitrate
+	}
+	return 0
+}
+
+func (x *EncodingProfile) GetCrf() int32 {
+	if x != nil {
+		return x.Crf
+	}
+	return 0
+}
+
+func (x *EncodingProfile) GetGop() int32 {
+	if x != nil {
+		return x.Gop
+	}
+	return 0
+}
+
+func (x *EncodingProfile) GetOutputWidth() int32 {
+	if x != nil {
+		return x.OutputWidth
+	}
+	return 0
+}
+
+func (x *EncodingProfile) GetOutputHeight() int32 {
+	if x != nil {
+		return x.OutputHeight
+	}
+	return 0
+}
+
+func (x *EncodingProfile) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *EncodingProfile) GetClear() []string {
+	if x != nil {
+		return x.Clear
+	}
+	return nil
+}
+
+// 未设置的字段不修改
+type UpdateSessionReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionID       string           `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"`
+	RtspURL         *string          `protobuf:"bytes,2,opt,name=rtspURL,proto3,oneof" json:"rtspURL,omitempty"`                 // 修改后重启拉流
+	Width           *int32           `protobuf:"varint,3,opt,name=width,proto3,oneof" json:"width,omitempty"`                    // 修改后重启拉流与推流
+	Height          *int32           `protobuf:"varint,4,opt,name=height,proto3,oneof" json:"height,omitempty"`                  // 修改后重启拉流与推流
+	Framerate       *int32           `protobuf:"varint,5,opt,name=framerate,proto3,oneof" json:"framerate,omitempty"`            // 修改后重启拉流与推流
+	DetectAIURL     *string          `protobuf:"bytes,6,opt,name=detectAIURL,proto3,oneof" json:"detectAIURL,omitempty"`         // 热更新
+	DetectStatus    *bool            `protobuf:"varint,7,opt,name=detectStatus,proto3,oneof" json:"detectStatus,omitempty"`      // 热更新
+	EncodingProfile *string          `protobuf:"bytes,8,opt,name=encodingProfile,proto3,oneof" json:"encodingProfile,omitempty"` // 修改后重启推流
+	Encoding        *EncodingProfile `protobuf:"bytes,9,opt,name=encoding,proto3" json:"encoding,omitempty"`                     // 修改后重启推流
+	Debug           *bool            `protobuf:"varint,10,opt,name=debug,proto3,oneof" json:"debug,omitempty"`                   // 调试模式，仅影响该会话，修改后重启拉流与推流
+	Schedule        *DetectSchedule  `protobuf:"bytes,11,opt,name=schedule,proto3" json:"schedule,omitempty"`                    // 识别时间计划，传空消息表示取消计划
+	Counting        *LineCounting    `protobuf:"bytes,12,opt,name=counting,proto3" json:"counting,omitempty"`                    // 虚拟线计数，修改后计数清零，传空消息表示关闭
+}
+
+func (x *UpdateSessionReq) Reset() {
+	*x = UpdateSessionReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_detect_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateSessionReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSessionReq) ProtoMessage() {}
+
+func (x *UpdateSessionReq) ProtoReflect() protoreflect.Message {
+	mi := &file_detect_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSessionReq.ProtoReflect.Descriptor instead.
+func (*UpdateSessionReq) Descriptor() ([]byte, []int) {
+	return file_detect_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UpdateSessionReq) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+func (x *UpdateSessionReq) GetRtspURL() string {
+	if x != nil && x.RtspURL != nil {
+		return *x.RtspURL
+	}
+	return ""
+}
+
+func (x *UpdateSessionReq) GetWidth() int32 {
+	if x != nil && x.Width != nil {
+		return *x.Width
+	}
+	return 0
+}
+
+func (x *UpdateSessionReq) GetHeight() int32 {
+	if x != nil && x.Height != nil {
+		return *x.Height
+	}
+	return 0
+}
+
+func (x *UpdateSessionReq) GetFramerate() int32 {
+	if x != nil && x.Framerate != nil {
+		return *x.Framerate
+	}
+	return 0
+}
+
+func (x *UpdateSessionReq) GetDetectAIURL() string {
+	if x != nil && x.DetectAIURL != nil {
+		return *x.DetectAIURL
+	}
+	return ""
+}
+
+func (x *UpdateSessionReq) GetDetectStatus() bool {
+	if x != nil && x.DetectStatus != nil {
+		return *x.DetectStatus
+	}
+	return false
+}
+
+func (x *UpdateSessionReq) GetEncodingProfile() string {
+	if x != nil && x.EncodingProfile != nil {
+		return *x.EncodingProfile
+	}
+	return ""
+}
+
+func (x *UpdateSessionReq) GetEncoding() *EncodingProfile {
+	if x != nil {
+		return x.Encoding
+	}
+	return nil
+}
+
+func (x *UpdateSessionReq) GetDebug() bool {
+	if x != nil && x.Debug != nil {
+		return *x.Debug
+	}
+	return false
+}
+
+func (x *UpdateSessionReq) GetSchedule() *DetectSchedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+func (x *UpdateSessionReq) GetCounting() *LineCounting {
+	if x != nil {
+		return x.Counting
+	}
+	return nil
+}
+
+type SessionIDReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionID string `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"`
+}
+
+func (x *SessionIDReq) Reset() {
+	*x = SessionIDReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_detect_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SessionIDReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionIDReq) ProtoMessage() {}
+
+func (x *SessionIDReq) ProtoReflect() protoreflect.Message {
+	mi := &file_detect_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionIDReq.ProtoReflect.Descriptor instead.
+func (*SessionIDReq) Descriptor() ([]byte, []int) {
+	return file_detect_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SessionIDReq) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+type SessionDesc struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id               string              `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	StreamKey        string              `protobuf:"bytes,2,opt,name=streamKey,proto3" json:"streamKey,omitempty"`
+	PushUrlPublic    string              `protobuf:"bytes,3,opt,name=pushUrlPublic,proto3" json:"pushUrlPublic,omitempty"`
+	DetectStatus     bool                `protobuf:"varint,4,opt,name=detectStatus,proto3" json:"detectStatus,omitempty"` // 识别状态 false 停止 true 识别
+	State            string              `protobuf:"bytes,5,opt,name=state,proto3" json:"state,omitempty"`                // 会话状态 preparing running reconnecting failed stopped
+	Width            int32               `protobuf:"varint,6,opt,name=width,proto3" json:"width,omitempty"`
+	Height           int32               `protobuf:"varint,7,opt,name=height,proto3" json:"height,omitempty"`
+	Framerate        int32               `protobuf:"varint,8,opt,name=framerate,proto3" json:"framerate,omitempty"`
+	ReconnectCount   int64               `protobuf:"varint,9,opt,name=reconnectCount,proto3" json:"reconnectCount,omitempty"`                                                                         // 累计重连次数
+	UptimeSeconds    float64             `protobuf:"fixed64,10,opt,name=uptimeSeconds,proto3" json:"uptimeSeconds,omitempty"`                                                                         // 运行时长 s
+	LastError        *SessionError       `protobuf:"bytes,11,opt,name=lastError,proto3" json:"lastError,omitempty"`                                                                                   // 最近一次错误
+	Errors           []*SessionError     `protobuf:"bytes,12,rep,name=errors,proto3" json:"errors,omitempty"`                                                                                         // 错误历史
+	Stats            *SessionStats       `protobuf:"bytes,13,opt,name=stats,proto3" json:"stats,omitempty"`                                                                                           // 流统计
+	Encoding         *EncodingProfile    `protobuf:"bytes,14,opt,name=encoding,proto3" json:"encoding,omitempty"`                                                                                     // 推流编码配置
+	Tenant           string              `protobuf:"bytes,15,opt,name=tenant,proto3" json:"tenant,omitempty"`                                                                                         // 所属租户
+	Labels           map[string]string   `protobuf:"bytes,16,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // 标签
+	Name             string              `protobuf:"bytes,17,opt,name=name,proto3" json:"name,omitempty"`                                                                                             // 展示名称
+	Site             string              `protobuf:"bytes,18,opt,name=site,proto3" json:"site,omitempty"`                                                                                             // 站点/位置
+	Metadata         string              `protobuf:"bytes,19,opt,name=metadata,proto3" json:"metadata,omitempty"`                                                                                     // 自定义元数据 JSON
+	Debug            bool                `protobuf:"varint,20,opt,name=debug,proto3" json:"debug,omitempty"`                                                                                          // 调试模式
+	StallRestarts    int64               `protobuf:"varint,21,opt,name=stallRestarts,proto3" json:"stallRestarts,omitempty"`                                                                          // 看门狗因卡流累计重启 FFmpeg 次数
+	Schedule         *DetectSchedule     `protobuf:"bytes,22,opt,name=schedule,proto3" json:"schedule,omitempty"`                                                                                     // 识别时间计划
+	ScheduleOverride *ScheduleOverride   `protobuf:"bytes,23,opt,name=scheduleOverride,proto3" json:"scheduleOverride,omitempty"`                                                                     // 生效中的手动覆盖
+	NextTransition   *ScheduleTransition `protobuf:"bytes,24,opt,name=nextTransition,proto3" json:"nextTransition,omitempty"`                                                                         // 下一次识别状态切换
+	Counting         *LineCounting       `protobuf:"bytes,25,opt,name=counting,proto3" json:"counting,omitempty"`                                                                                     // 虚拟线计数配置
+}
+
+func (x *SessionDesc) Reset() {
+	*x = SessionDesc{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_detect_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SessionDesc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionDesc) ProtoMessage() {}
+
+func (x *SessionDesc) ProtoReflect() protoreflect.Message {
+	mi := &file_detect_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionDesc.ProtoReflect.Descriptor instead.
+func (*SessionDesc) Descriptor() ([]byte, []int) {
+	return file_detect_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SessionDesc) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SessionDesc) GetStreamKey() string {
+	if x != nil {
+		return x.StreamKey
+	}
+	return ""
+}
+
+func (x *SessionDesc) GetPushUrlPublic() string {
+	if x != nil {
+		return x.PushUrlPublic
+	}
+	return ""
+}
+
+func (x *SessionDesc) GetDetectStatus() bool {
+	if x != nil {
+		return x.DetectStatus
+	}
+	return false
+}
+
+func (x *SessionDesc) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *SessionDesc) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *SessionDesc) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *SessionDesc) GetFramerate() int32 {
+	if x != nil {
+		return x.Framerate
+	}
+	return 0
+}
+
+func (x *SessionDesc) GetReconnectCount() int64 {
+	if x != nil {
+		return x.ReconnectCount
+	}
+	return 0
+}
+
+func (x *SessionDesc) GetUptimeSeconds() float64 {
+	if x != nil {
+		return x.UptimeSeconds
+	}
+	return 0
+}
+
+func (x *SessionDesc) GetLastError() *SessionError {
+	if x != nil {
+		return x.LastError
+	}
+	return nil
+}
+
+func (x *SessionDesc) GetErrors() []*SessionError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *SessionDesc) GetStats() *SessionStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *SessionDesc) GetEncoding() *EncodingProfile {
+	if x != nil {
+		return x.Encoding
+	}
+	return nil
+}
+
+func (x *SessionDesc) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *SessionDesc) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *SessionDesc) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SessionDesc) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+func (x *SessionDesc) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+func (x *SessionDesc) GetDebug() bool {
+	if x != nil {
+		return x.Debug
+	}
+	return false
+}
+
+func (x *SessionDesc) GetStallRestarts() int64 {
+	if x != nil {
+		return x.StallRestarts
+	}
+	return 0
+}
+
+func (x *SessionDesc) GetSchedule() *DetectSchedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+func (x *SessionDesc) GetScheduleOverride() *ScheduleOverride {
+	if x != nil {
+		return x.ScheduleOverride
+	}
+	return nil
+}
+
+func (x *SessionDesc) GetNextTransition() *ScheduleTransition {
+	if x != nil {
+		return x.NextTransition
+	}
+	return nil
+}
+
+func (x *SessionDesc) GetCounting() *LineCounting {
+	if x != nil {
+		return x.Counting
+	}
+	return nil
+}
+
+type SessionError struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Time     int64  `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`         // 首次出现时间 Unix 毫秒
+	Stage    string `protobuf:"bytes,2,opt,name=stage,proto3" json:"stage,omitempty"`        // 出错阶段：pull 拉流 push 推流 detect 识别 prepare 准备
+	Message  string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`    // 首次出现时的错误信息
+	Code     string `protobuf:"bytes,4,opt,name=code,proto3" json:"code,omitempty"`          // 已知错误类型，如 FFmpeg 日志解析出的 unauthorized
+	Count    int32  `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"`       // 连续出现次数
+	LastSeen int64  `protobuf:"varint,6,opt,name=lastSeen,proto3" json:"lastSeen,omitempty"` // 最近一次出现时间 Unix 毫秒
+}
+
+func (x *SessionError) Reset() {
+	*x = SessionError{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_detect_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SessionError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionError) ProtoMessage() {}
+
+func (x *SessionError) ProtoReflect() protoreflect.Message {
+	mi := &file_detect_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionError.ProtoReflect.Descriptor instead.
+func (*SessionError) Descriptor() ([]byte, []int) {
+	return file_detect_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SessionError) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *SessionError) GetStage() string {
+	if x != nil {
+		return x.Stage
+	}
+	return ""
+}
+
+func (x *SessionError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SessionError) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *SessionError) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *SessionError) GetLastSeen() int64 {
+	if x != nil {
+		return x.LastSeen
+	}
+	return 0
+}
+
+type SessionStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	InputFps          float64 `protobuf:"fixed64,1,opt,name=inputFps,proto3" json:"inputFps,omitempty"`                  // 拉流读帧帧率
+	OutputFps         float64 `protobuf:"fixed64,2,opt,name=outputFps,proto3" json:"outputFps,omitempty"`                // 推流写帧帧率
+	DetectFps         float64 `protobuf:"fixed64,3,opt,name=detectFps,proto3" json:"detectFps,omitempty"`                // 识别完成帧率
+	AvgAILatencyMs    float64 `protobuf:"fixed64,4,opt,name=avgAILatencyMs,proto3" json:"avgAILatencyMs,omitempty"`      // 识别请求平均耗时 ms
+	LastFrameReadAt   int64   `protobuf:"varint,5,opt,name=lastFrameReadAt,proto3" json:"lastFrameReadAt,omitempty"`     // 最近一次读到帧 Unix 毫秒，0 表示尚无
+	LastFramePushedAt int64   `protobuf:"varint,6,opt,name=lastFramePushedAt,proto3" json:"lastFramePushedAt,omitempty"` // 最近一次推流写帧 Unix 毫秒，0 表示尚无
+}
+
+func (x *SessionStats) Reset() {
+	*x = SessionStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_detect_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SessionStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionStats) ProtoMessage() {}
+
+func (x *SessionStats) ProtoReflect() protoreflect.Message {
+	mi := &file_detect_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionStats.ProtoReflect.Descriptor instead.
+func (*SessionStats) Descriptor() ([]byte, []int) {
+	return file_detect_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SessionStats) GetInputFps() float64 {
+	if x != nil {
+		return x.InputFps
+	}
+	return 0
+}
+
+func (x *SessionStats) GetOutputFps() float64 {
+	if x != nil {
+		return x.OutputFps
+	}
+	return 0
+}
+
+func (x *SessionStats) GetDetectFps() float64 {
+	if x != nil {
+		return x.DetectFps
+	}
+	return 0
+}
+
+func (x *SessionStats) GetAvgAILatencyMs() float64 {
+	if x != nil {
+		return x.AvgAILatencyMs
+	}
+	return 0
+}
+
+func (x *SessionStats) GetLastFrameReadAt() int64 {
+	if x != nil {
+		return x.LastFrameReadAt
+	}
+	return 0
+}
+
+func (x *SessionStats) GetLastFramePushedAt() int64 {
+	if x != nil {
+		return x.LastFramePushedAt
+	}
+	return 0
+}
+
+type GetSessionDescByIDResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Exists  bool         `protobuf:"varint,1,opt,name=exists,proto3" json:"exists,omitempty"` // 是否存在
+	Session *SessionDesc `protobuf:"bytes,2,opt,name=session,proto3" json:"session,omitempty"`
+}
+
+func (x *GetSessionDescByIDResp) Reset() {
+	*x = GetSessionDescByIDResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_detect_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSessionDescByIDResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSessionDescByIDResp) ProtoMessage() {}
+
+func (x *GetSessionDescByIDResp) ProtoReflect() protoreflect.Message {
+	mi := &file_detect_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSessionDescByIDResp.ProtoReflect.Descriptor instead.
+func (*GetSessionDescByIDResp) Descriptor() ([]byte, []int) {
+	return file_detect_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetSessionDescByIDResp) GetExists() bool {
+	if x != nil {
+		return x.Exists
+	}
+	return false
+}
+
+func (x *GetSessionDescByIDResp) GetSession() *SessionDesc {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+type AllSessionDescResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sessions []*SessionDesc `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+}
+
+func (x *AllSessionDescResp) Reset() {
+	*x = AllSessionDescResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_detect_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AllSessionDescResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllSessionDescResp) ProtoMessage() {}
+
+func (x *AllSessionDescResp) ProtoReflect() protoreflect.Message {
+	mi := &file_detect_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllSessionDescResp.ProtoReflect.Descriptor instead.
+func (*AllSessionDescResp) Descriptor() ([]byte, []int) {
+	return file_detect_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AllSessionDescResp) GetSessions() []*SessionDesc {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+// 零值字段不过滤
+type ListSessionReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Offset       uint32   `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`                   // 跳过的条数，需同时指定 limit
+	Limit        uint32   `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`                     // 每页条数 5-20，0 表示不分页
+	Status       string   `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`                    // 会话状态 preparing running reconnecting failed stopped
+	DetectStatus *bool    `protobuf:"varint,4,opt,name=detectStatus,proto3,oneof" json:"detectStatus,omitempty"` // 识别状态
+	Labels       []string `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty"`                    // 标签 key 或 key=value，需全部匹配
+	Q            string   `protobuf:"bytes,6,opt,name=q,proto3" json:"q,omitempty"`                              // ID、名称或拉流地址子串
+	Sort         string   `protobuf:"bytes,7,opt,name=sort,proto3" json:"sort,omitempty"`                        // id state uptime，- 前缀表示降序
+	Site         string   `protobuf:"bytes,8,opt,name=site,proto3" json:"site,omitempty"`                        // 站点
+}
+
+func (x *ListSessionReq) Reset() {
+	*x = ListSessionReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_detect_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSessionReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionReq) ProtoMessage() {}
+
+func (x *ListSessionReq) ProtoReflect() protoreflect.Message {
+	mi := &file_detect_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionReq.ProtoReflect.Descriptor instead.
+func (*ListSessionReq) Descriptor() ([]byte, []int) {
+	return file_detect_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListSessionReq) GetOffset() uint32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListSessionReq) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListSessionReq) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListSessionReq) GetDetectStatus() bool {
+	if x != nil && x.DetectStatus != nil {
+		return *x.DetectStatus
+	}
+	return false
+}
+
+func (x *ListSessionReq) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *ListSessionReq) GetQ() string {
+	if x != nil {
+		return x.Q
+	}
+	return ""
+}
+
+func (x *ListSessionReq) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListSessionReq) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+// 零值字段不过滤
+type AuditQueryReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Offset    uint32 `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"` // 跳过的条数，需同时指定 limit
+	Limit     uint32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`   // 每页条数 5-20，0 表示不分页
+	SessionID string `protobuf:"bytes,3,opt,name=sessionID,proto3" json:"sessionID,omitempty"`
+	Action    string `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"` // 动作 created prepared updated detect_started detect_stopped reconnecting restarted failed stopped removed
+	Actor     string `protobuf:"bytes,5,opt,name=actor,proto3" json:"actor,omitempty"`   // 调用方，如 api-key:ops@t1
+	From      int64  `protobuf:"varint,6,opt,name=from,proto3" json:"from,omitempty"`    // 起始时间（含）Unix 毫秒
+	To        int64  `protobuf:"varint,7,opt,name=to,proto3" json:"to,omitempty"`        // 结束时间（不含）Unix 毫秒
+}
+
+func (x *AuditQueryReq) Reset() {
+	*x = AuditQueryReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_detect_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuditQueryReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditQueryReq) ProtoMessage() {}
+
+func (x *AuditQueryReq) ProtoReflect() protoreflect.Message {
+	mi := &file_detect_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditQueryReq.ProtoReflect.Descriptor instead.
+func (*AuditQueryReq) Descriptor() ([]byte, []int) {
+	return file_detect_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *AuditQueryReq) GetOffset() uint32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *AuditQueryReq) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *AuditQueryReq) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+func (x *AuditQueryReq) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AuditQueryReq) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *AuditQueryReq) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *AuditQueryReq) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+type AuditEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Time      int64  `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"` // Unix 毫秒
+	SessionID string `protobuf:"bytes,2,opt,name=sessionID,proto3" json:"sessionID,omitempty"`
+	Tenant    string `protobuf:"bytes,3,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	Action    string `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	Actor     string `protobuf:"bytes,5,opt,name=actor,proto3" json:"actor,omitempty"` // 调用方，内部状态转换为 system
+	Peer      string `protobuf:"bytes,6,opt,name=peer,proto3" json:"peer,omitempty"`   // 调用方地址
+	Reason    string `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *AuditEvent) Reset() {
+	*x = AuditEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_detect_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuditEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEvent) ProtoMessage() {}
+
+func (x *AuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_detect_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEvent.ProtoReflect.Descriptor instead.
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return file_detect_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *AuditEvent) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type AuditEventsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Total  int64         `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"` // 过滤后总数
+	Events []*AuditEvent `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+}
+
+func (x *AuditEventsResp) Reset() {
+	*x = AuditEventsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_detect_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuditEventsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEventsResp) ProtoMessage() {}
+
+func (x *AuditEventsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_detect_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEventsResp.ProtoReflect.Descriptor instead.
+func (*AuditEventsResp) Descriptor() ([]byte, []int) {
+	return file_detect_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *AuditEventsResp) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *AuditEventsResp) GetEvents() []*AuditEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type EventQueryReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Offset        uint32  `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"` // 跳过的条数，需同时指定 limit
+	Limit         uint32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`   // 每页条数 5-20，0 表示不分页
+	SessionID     string  `protobuf:"bytes,3,opt,name=sessionID,proto3" json:"sessionID,omitempty"`
+	Label         string  `protobuf:"bytes,4,opt,name=label,proto3" json:"label,omitempty"`                   // 识别类别，如 person
+	MinConfidence float64 `protobuf:"fixed64,5,opt,name=minConfidence,proto3" json:"minConfidence,omitempty"` // 最低置信度，与 label 同时设置时需同一结果满足
+	From          int64   `protobuf:"varint,6,opt,name=from,proto3" json:"from,omitempty"`                    // 起始时间（含）Unix 毫秒
+	To            int64   `protobuf:"varint,7,opt,name=to,proto3" json:"to,omitempty"`                        // 结束时间（不含）Unix 毫秒
+}
+
+func (x *EventQueryReq) Reset() {
+	*x = EventQueryReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_detect_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EventQueryReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventQueryReq) ProtoMessage() {}
+
+func (x *EventQueryReq) ProtoReflect() protoreflect.Message {
+	mi := &file_detect_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventQueryReq.ProtoReflect.Descriptor instead.
+func (*EventQueryReq) Descriptor() ([]byte, []int) {
+	return file_detect_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *EventQueryReq) GetOffset() uint32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *EventQueryReq) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *EventQueryReq) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+func (x *EventQueryReq) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *EventQueryReq) GetMinConfidence() float64 {
+	if x != nil {
+		return x.MinConfidence
+	}
+	return 0
+}
+
+func (x *EventQueryReq) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *EventQueryReq) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+type DetectionResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	X1    int32   `protobuf:"varint,1,opt,name=x1,proto3" json:"x1,omitempty"`
+	Y1    int32   `protobuf:"varint,2,opt,name=y1,proto3" json:"y1,omitempty"`
+	X2    int32   `protobuf:"varint,3,opt,name=x2,proto3" json:"x2,omitempty"`
+	Y2    int32   `protobuf:"varint,4,opt,name=y2,proto3" json:"y2,omitempty"`
+	Label string  `protobuf:"bytes,5,opt,name=label,proto3" json:"label,omitempty"`
+	Conf  float64 `protobuf:"fixed64,6,opt,name=conf,proto3" json:"conf,omitempty"`
+}
+
+func (x *DetectionResult) Reset() {
+	*x = DetectionResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_detect_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DetectionResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetectionResult) ProtoMessage() {}
+
+func (x *DetectionResult) ProtoReflect() protoreflect.Message {
+	mi := &file_detect_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetectionResult.ProtoReflect.Descriptor instead.
+func (*DetectionResult) Descriptor() ([]byte, []int) {
+	return file_detect_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *DetectionResult) GetX1() int32 {
+	if x != nil {
+		return x.X1
+	}
+	return 0
+}
+
+func (x *DetectionResult) GetY1() int32 {
+	if x != nil {
+		return x.Y1
+	}
+	return 0
+}
+
+func (x *DetectionResult) GetX2() int32 {
+	if x != nil {
+		return x.X2
+	}
+	return 0
+}
+
+func (x *DetectionResult) GetY2() int32 {
+	if x != nil {
+		return x.Y2
+	}
+	return 0
+}
+
+func (x *DetectionResult) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *DetectionResult) GetConf() float64 {
+	if x != nil {
+		return x.Conf
+	}
+	return 0
+}
+
+type DetectionEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        string             `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind      string             `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"` // 事件类型 detection
+	SessionID string             `protobuf:"bytes,3,opt,name=sessionID,proto3" json:"sessionID,omitempty"`
+	Tenant    string             `protobuf:"bytes,4,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	Name      string             `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"` // 会话展示名称
+	Site      string             `protobuf:"bytes,6,opt,name=site,proto3" json:"site,omitempty"`
+	Labels    map[string]string  `protobuf:"bytes,7,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // 会话标签
+	Metadata  string             `protobuf:"bytes,8,opt,name=metadata,proto3" json:"metadata,omitempty"`                                                                                     // 会话自定义元数据 JSON
+	Time      int64              `protobuf:"varint,9,opt,name=time,proto3" json:"time,omitempty"`                                                                                            // Unix 毫秒
+	Results   []*DetectionResult `protobuf:"bytes,10,rep,name=results,proto3" json:"results,omitempty"`
+	Snapshot  string             `protobuf:"bytes,11,opt,name=snapshot,proto3" json:"snapshot,omitempty"` // 快照文件路径，可选
+}
+
+func (x *DetectionEvent) Reset() {
+	*x = DetectionEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_detect_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DetectionEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetectionEvent) ProtoMessage() {}
+
+func (x *DetectionEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_detect_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetectionEvent.ProtoReflect.Descriptor instead.
+func (*DetectionEvent) Descriptor() ([]byte, []int) {
+	return file_detect_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DetectionEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DetectionEvent) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *DetectionEvent) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+func (x *DetectionEvent) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *DetectionEvent) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DetectionEvent) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+func (x *DetectionEvent) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *DetectionEvent) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+func (x *DetectionEvent) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *DetectionEvent) GetResults() []*DetectionResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *DetectionEvent) GetSnapshot() string {
+	if x != nil {
+		return x.Snapshot
+	}
+	return ""
+}
+
+type EventsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Total  int64             `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"` // 过滤后总数
+	Events []*DetectionEvent `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+}
+
+func (x *EventsResp) Reset() {
+	*x = EventsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_detect_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EventsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventsResp) ProtoMessage() {}
+
+func (x *EventsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_detect_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventsResp.ProtoReflect.Descriptor instead.
+func (*EventsResp) Descriptor() ([]byte, []int) {
+	return file_detect_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *EventsResp) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *EventsResp) GetEvents() []*DetectionEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type DetectStatsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionID string `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"`
+	From      int64  `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`        // 起始时间（含）Unix 毫秒，0 表示 to 之前 24 小时
+	To        int64  `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`            // 结束时间（不含）Unix 毫秒，0 表示当前时间
+	Bucket    string `protobuf:"bytes,4,opt,name=bucket,proto3" json:"bucket,omitempty"`     // 时间桶粒度 minute hour day，默认 hour
+	Timezone  string `protobuf:"bytes,5,opt,name=timezone,proto3" json:"timezone,omitempty"` // 小时与天对齐使用的 IANA 时区，默认服务器本地时区
+}
+
+func (x *DetectStatsReq) Reset() {
+	*x = DetectStatsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_detect_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DetectStatsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetectStatsReq) ProtoMessage() {}
+
+func (x *DetectStatsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_detect_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetectStatsReq.ProtoReflect.Descriptor instead.
+func (*DetectStatsReq) Descriptor() ([]byte, []int) {
+	return file_detect_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DetectStatsReq) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+func (x *DetectStatsReq) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *DetectStatsReq) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *DetectStatsReq) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *DetectStatsReq) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+type DetectStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Start  int64                  `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`                                                                                          // 时间桶起始时间 Unix 毫秒
+	Frames int64                  `protobuf:"varint,2,opt,name=frames,proto3" json:"frames,omitempty"`                                                                                        // 识别帧数
+	Peak   int32                  `protobuf:"varint,3,opt,name=peak,proto3" json:"peak,omitempty"`                                                                                            // 单帧最大目标数
+	Labels map[string]*LabelStats `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // 按类别统计
+}
+
+func (x *DetectStats) Reset() {
+	*x = DetectStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_detect_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DetectStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetectStats) ProtoMessage() {}
+
+func (x *DetectStats) ProtoReflect() protoreflect.Message {
+	mi := &file_detect_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetectStats.ProtoReflect.Descriptor instead.
+func (*DetectStats) Descriptor() ([]byte, []int) {
+	return file_detect_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DetectStats) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *DetectStats) GetFrames() int64 {
+	if x != nil {
+		return x.Frames
+	}
+	return 0
+}
+
+func (x *DetectStats) GetPeak() int32 {
+	if x != nil {
+		return x.Peak
+	}
+	return 0
+}
+
+func (x *DetectStats) GetLabels() map[string]*LabelStats {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type LabelStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Count         int64   `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`                  // 识别目标累计数
+	Peak          int32   `protobuf:"varint,2,opt,name=peak,proto3" json:"peak,omitempty"`                    // 单帧最大目标数
+	AvgConfidence float64 `protobuf:"fixed64,3,opt,name=avgConfidence,proto3" json:"avgConfidence,omitempty"` // 平均置信度
+}
+
+func (x *LabelStats) Reset() {
+	*x = LabelStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_detect_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LabelStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LabelStats) ProtoMessage() {}
+
+func (x *LabelStats) ProtoReflect() protoreflect.Message {
+	mi := &file_detect_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LabelStats.ProtoReflect.Descriptor instead.
+func (*LabelStats) Descriptor() ([]byte, []int) {
+	return file_detect_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *LabelStats) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *LabelStats) GetPeak() int32 {
+	if x != nil {
+		return x.Peak
+	}
+	return 0
+}
+
+func (x *LabelStats) GetAvgConfidence() float64 {
+	if x != nil {
+		return x.AvgConfidence
+	}
+	return 0
+}
+
+type DetectStatsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Bucket  string         `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	From    int64          `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	To      int64          `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+	Buckets []*DetectStats `protobuf:"bytes,4,rep,name=buckets,proto3" json:"buckets,omitempty"` // 有数据的时间桶，按时间升序
+	Total   *DetectStats   `protobuf:"bytes,5,opt,name=total,proto3" json:"total,omitempty"`     // 区间合计
+}
+
+func (x *DetectStatsResp) Reset() {
+	*x = DetectStatsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_detect_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DetectStatsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetectStatsResp) ProtoMessage() {}
+
+func (x *DetectStatsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_detect_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetectStatsResp.ProtoReflect.Descriptor instead.
+func (*DetectStatsResp) Descriptor() ([]byte, []int) {
+	return file_detect_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DetectStatsResp) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *DetectStatsResp) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *DetectStatsResp) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *DetectStatsResp) GetBuckets() []*DetectStats {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+func (x *DetectStatsResp) GetTotal() *DetectStats {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+type ListSessionResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Total    int64          `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"` // 过滤后总数
+	Sessions []*SessionDesc `protobuf:"bytes,2,rep,name=sessions,proto3" json:"sessions,omitempty"`
+}
+
+func (x *ListSessionResp) Reset() {
+	*x = ListSessionResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_detect_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSessionResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionResp) ProtoMessage() {}
+
+func (x *ListSessionResp) ProtoReflect() protoreflect.Message {
+	mi := &file_detect_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionResp.ProtoReflect.Descriptor instead.
+func (*ListSessionResp) Descriptor() ([]byte, []int) {
+	return file_detect_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListSessionResp) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListSessionResp) GetSessions() []*SessionDesc {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+// sessions 与 csv 二选一，csv 为摄像头清单，表头与 CreateSessionReq JSON 字段一致
+type BulkCreateSessionReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sessions []*CreateSessionReq `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	Csv      string              `protobuf:"bytes,2,opt,name=csv,proto3" json:"csv,omitempty"`
+}
+
+func (x *BulkCreateSessionReq) Reset() {
+	*x = BulkCreateSessionReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_detect_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BulkCreateSessionReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkCreateSessionReq) ProtoMessage() {}
+
+func (x *BulkCreateSessionReq) ProtoReflect() protoreflect.Message {
+	mi := &file_detect_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkCreateSessionReq.ProtoReflect.Descriptor instead.
+func (*BulkCreateSessionReq) Descriptor() ([]byte, []int) {
+	return file_detect_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *BulkCreateSessionReq) GetSessions() []*CreateSessionReq {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+func (x *BulkCreateSessionReq) GetCsv() string {
+	if x != nil {
+		return x.Csv
+	}
+	return ""
+}
+
+// 按 ID 列表与标签选择会话，结果取并集
+type BulkSelectorReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ids    []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	Labels []string `protobuf:"bytes,2,rep,name=labels,proto3" json:"labels,omitempty"` // 标签 key 或 key=value，需全部匹配
+}
+
+func (x *BulkSelectorReq) Reset() {
+	*x = BulkSelectorReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_detect_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BulkSelectorReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkSelectorReq) ProtoMessage() {}
+
+func (x *BulkSelectorReq) ProtoReflect() protoreflect.Message {
+	mi := &file_detect_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkSelectorReq.ProtoReflect.Descriptor instead.
+func (*BulkSelectorReq) Descriptor() ([]byte, []int) {
+	return file_detect_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *BulkSelectorReq) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *BulkSelectorReq) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type BulkItemResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id      string       `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Ok      bool         `protobuf:"varint,2,opt,name=ok,proto3" json:"ok,omitempty"`
+	Error   string       `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	Session *SessionDesc `protobuf:"bytes,4,opt,name=session,proto3" json:"session,omitempty"` // 批量创建成功时返回
+}
+
+func (x *BulkItemResult) Reset() {
+	*x = BulkItemResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_detect_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BulkItemResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkItemResult) ProtoMessage() {}
+
+func (x *BulkItemResult) ProtoReflect() protoreflect.Message {
+	mi := &file_detect_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkItemResult.ProtoReflect.Descriptor instead.
+func (*BulkItemResult) Descriptor() ([]byte, []int) {
+	return file_detect_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *BulkItemResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BulkItemResult) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *BulkItemResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *BulkItemResult) GetSession() *SessionDesc {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+type BulkResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Total     int32             `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Succeeded int32             `protobuf:"varint,2,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Failed    int32             `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	Items     []*BulkItemResult `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *BulkResp) Reset() {
+	*x = BulkResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_detect_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BulkResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkResp) ProtoMessage() {}
+
+func (x *BulkResp) ProtoReflect() protoreflect.Message {
+	mi := &file_detect_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkResp.ProtoReflect.Descriptor instead.
+func (*BulkResp) Descriptor() ([]byte, []int) {
+	return file_detect_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *BulkResp) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *BulkResp) GetSucceeded() int32 {
+	if x != nil {
+		return x.Succeeded
+	}
+	return 0
+}
+
+func (x *BulkResp) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *BulkResp) GetItems() []*BulkItemResult {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type GenericResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok bool `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+}
+
+func (x *GenericResp) Reset() {
+	*x = GenericResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_detect_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GenericResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenericResp) ProtoMessage() {}
+
+func (x *GenericResp) ProtoReflect() protoreflect.Message {
+	mi := &file_detect_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenericResp.ProtoReflect.Descriptor instead.
+func (*GenericResp) Descriptor() ([]byte, []int) {
+	return file_detect_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GenericResp) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+type Empty struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *Empty) Reset() {
+	*x = Empty{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_detect_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Empty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Empty) ProtoMessage() {}
+
+func (x *Empty) ProtoReflect() protoreflect.Message {
+	mi := &file_detect_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
+	return file_detect_proto_rawDescGZIP(), []int{37}
+}
+
+var File_detect_proto protoreflect.FileDescriptor
+
+var file_detect_proto_rawDesc = []byte{
+	0x0a, 0x0c, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02,
+	0x70, 0x62, 0x22, 0x9a, 0x04, 0x0a, 0x10, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x74, 0x73, 0x70, 0x55,
+	0x52, 0x4c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x74, 0x73, 0x70, 0x55, 0x52,
+	0x4c, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12,
+	0x1c, 0x0a, 0x09, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x09, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x0a,
+	0x0a, 0x72, 0x65, 0x74, 0x72, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0a, 0x72, 0x65, 0x74, 0x72, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x12, 0x28, 0x0a,
+	0x0f, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67,
+	0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x2f, 0x0a, 0x08, 0x65, 0x6e, 0x63, 0x6f, 0x64,
+	0x69, 0x6e, 0x67, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x45,
+	0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x08,
+	0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x38, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65,
+	0x6c, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x2e, 0x4c,
+	0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65,
+	0x6c, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x74, 0x65, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x69, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x2e, 0x0a, 0x08, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x65,
+	0x74, 0x65, 0x63, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x08, 0x73, 0x63,
+	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x2c, 0x0a, 0x08, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x69,
+	0x6e, 0x67, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69,
+	0x6e, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x08, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x69, 0x6e, 0x67, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0x68, 0x0a, 0x0c, 0x4c, 0x69, 0x6e, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x12,
+	0x26, 0x0a, 0x05, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x4c, 0x69, 0x6e, 0x65,
+	0x52, 0x05, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x73, 0x65, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x65, 0x73, 0x65, 0x74, 0x12, 0x1a, 0x0a,
+	0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x22, 0x7a, 0x0a, 0x0c, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x69, 0x6e, 0x67, 0x4c, 0x69, 0x6e, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x0e, 0x0a,
+	0x02, 0x78, 0x31, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x78, 0x31, 0x12, 0x0e, 0x0a,
+	0x02, 0x79, 0x31, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x79, 0x31, 0x12, 0x0e, 0x0a,
+	0x02, 0x78, 0x32, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x78, 0x32, 0x12, 0x0e, 0x0a,
+	0x02, 0x79, 0x32, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x79, 0x32, 0x12, 0x16, 0x0a,
+	0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x73, 0x22, 0x2e, 0x0a, 0x0a, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x02, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x03, 0x6f, 0x75, 0x74, 0x22, 0xbf, 0x01, 0x0a, 0x09, 0x4c, 0x69, 0x6e, 0x65, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x75, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6f, 0x75, 0x74, 0x12, 0x31, 0x0a, 0x06, 0x6c, 0x61, 0x62,
+	0x65, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x62, 0x2e, 0x4c,
+	0x69, 0x6e, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x1a, 0x49, 0x0a, 0x0b,
+	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x24, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70,
+	0x62, 0x2e, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x69, 0x0a, 0x0e, 0x4c, 0x69, 0x6e, 0x65, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x69, 0x6e,
+	0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x12,
+	0x1c, 0x0a, 0x09, 0x6e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x6e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x73, 0x65, 0x74, 0x12, 0x23, 0x0a,
+	0x05, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70,
+	0x62, 0x2e, 0x4c, 0x69, 0x6e, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x05, 0x6c, 0x69, 0x6e,
+	0x65, 0x73, 0x22, 0x84, 0x01, 0x0a, 0x0e, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x53, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e,
+	0x65, 0x12, 0x2c, 0x0a, 0x07, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x52, 0x07, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x73, 0x12,
+	0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x74, 0x6f, 0x70, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x74, 0x6f, 0x70, 0x22, 0x4c, 0x0a, 0x0e, 0x53, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x12, 0x0a, 0x04, 0x64,
+	0x61, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x79, 0x73, 0x12,
+	0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x22, 0x40, 0x0a, 0x10, 0x53, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x64,
+	0x65, 0x74, 0x65, 0x63, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x65, 0x74,
+	0x65, 0x63, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x22, 0x40, 0x0a, 0x12, 0x53, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74,
+	0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x22, 0x71, 0x0a, 0x13, 0x53,
+	0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x52,
+	0x65, 0x71, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44,
+	0x12, 0x1b, 0x0a, 0x06, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x48, 0x00, 0x52, 0x06, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x88, 0x01, 0x01, 0x12, 0x14, 0x0a,
+	0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x75, 0x6e,
+	0x74, 0x69, 0x6c, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x22, 0x85,
+	0x02, 0x0a, 0x0f, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x50, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x73,
+	0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x65, 0x73, 0x65, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x75, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x75, 0x6e, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x69, 0x74, 0x72, 0x61, 0x74, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x62, 0x69, 0x74, 0x72, 0x61, 0x74, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x63, 0x72, 0x66, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x63, 0x72, 0x66,
+	0x12, 0x10, 0x0a, 0x03, 0x67, 0x6f, 0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x67,
+	0x6f, 0x70, 0x12, 0x20, 0x0a, 0x0b, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x57, 0x69, 0x64, 0x74,
+	0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x57,
+	0x69, 0x64, 0x74, 0x68, 0x12, 0x22, 0x0a, 0x0c, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x48, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x6f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d,
+	0x61, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x05, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x22, 0xc1, 0x04, 0x0a, 0x10, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x12, 0x1c, 0x0a, 0x09, 0x73,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x1d, 0x0a, 0x07, 0x72, 0x74, 0x73,
+	0x70, 0x55, 0x52, 0x4c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x07, 0x72, 0x74,
+	0x73, 0x70, 0x55, 0x52, 0x4c, 0x88, 0x01, 0x01, 0x12, 0x19, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74,
+	0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x48, 0x01, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68,
+	0x88, 0x01, 0x01, 0x12, 0x1b, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x05, 0x48, 0x02, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x88, 0x01, 0x01,
+	0x12, 0x21, 0x0a, 0x09, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x05, 0x48, 0x03, 0x52, 0x09, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x74, 0x65,
+	0x88, 0x01, 0x01, 0x12, 0x25, 0x0a, 0x0b, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x41, 0x49, 0x55,
+	0x52, 0x4c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x48, 0x04, 0x52, 0x0b, 0x64, 0x65, 0x74, 0x65,
+	0x63, 0x74, 0x41, 0x49, 0x55, 0x52, 0x4c, 0x88, 0x01, 0x01, 0x12, 0x27, 0x0a, 0x0c, 0x64, 0x65,
+	0x74, 0x65, 0x63, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08,
+	0x48, 0x05, 0x52, 0x0c, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x88, 0x01, 0x01, 0x12, 0x2d, 0x0a, 0x0f, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x50,
+	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x48, 0x06, 0x52, 0x0f,
+	0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x88,
+	0x01, 0x01, 0x12, 0x2f, 0x0a, 0x08, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69,
+	0x6e, 0x67, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x08, 0x65, 0x6e, 0x63, 0x6f, 0x64,
+	0x69, 0x6e, 0x67, 0x12, 0x19, 0x0a, 0x05, 0x64, 0x65, 0x62, 0x75, 0x67, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x08, 0x48, 0x07, 0x52, 0x05, 0x64, 0x65, 0x62, 0x75, 0x67, 0x88, 0x01, 0x01, 0x12, 0x2e,
+	0x0a, 0x08, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x53, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x52, 0x08, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x2c,
+	0x0a, 0x08, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x6e, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x69,
+	0x6e, 0x67, 0x52, 0x08, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x0a, 0x0a, 0x08,
+	0x5f, 0x72, 0x74, 0x73, 0x70, 0x55, 0x52, 0x4c, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x77, 0x69, 0x64,
+	0x74, 0x68, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x42, 0x0c, 0x0a,
+	0x0a, 0x5f, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x74, 0x65, 0x42, 0x0e, 0x0a, 0x0c, 0x5f,
+	0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x41, 0x49, 0x55, 0x52, 0x4c, 0x42, 0x0f, 0x0a, 0x0d, 0x5f,
+	0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x42, 0x12, 0x0a, 0x10,
+	0x5f, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x42, 0x08, 0x0a, 0x06, 0x5f, 0x64, 0x65, 0x62, 0x75, 0x67, 0x22, 0x2c, 0x0a, 0x0c, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x52, 0x65, 0x71, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x22, 0xd0, 0x07, 0x0a, 0x0b, 0x53, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x4b, 0x65, 0x79, 0x12, 0x24, 0x0a, 0x0d, 0x70, 0x75, 0x73, 0x68, 0x55, 0x72,
+	0x6c, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70,
+	0x75, 0x73, 0x68, 0x55, 0x72, 0x6c, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x12, 0x22, 0x0a, 0x0c,
+	0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0c, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06,
+	0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x68, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x74,
+	0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x72, 0x61,
+	0x74, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x72, 0x65, 0x63, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x75, 0x70,
+	0x74, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0d, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73,
+	0x12, 0x2e, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x12, 0x28, 0x0a, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x52, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x12, 0x26, 0x0a, 0x05, 0x73, 0x74,
+	0x61, 0x74, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x73, 0x12, 0x2f, 0x0a, 0x08, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x0e,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69,
+	0x6e, 0x67, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x08, 0x65, 0x6e, 0x63, 0x6f, 0x64,
+	0x69, 0x6e, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x18, 0x0f, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x12, 0x33, 0x0a, 0x06, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x62,
+	0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x2e, 0x4c, 0x61, 0x62,
+	0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x74, 0x65, 0x18, 0x12, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x73, 0x69, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x13, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x62, 0x75, 0x67, 0x18, 0x14, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x05, 0x64, 0x65, 0x62, 0x75, 0x67, 0x12, 0x24, 0x0a, 0x0d, 0x73, 0x74,
+	0x61, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x73, 0x18, 0x15, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0d, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x73,
+	0x12, 0x2e, 0x0a, 0x08, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x18, 0x16, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x53, 0x63,
+	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x08, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x12, 0x40, 0x0a, 0x10, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x4f, 0x76, 0x65, 0x72,
+	0x72, 0x69, 0x64, 0x65, 0x18, 0x17, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x62, 0x2e,
+	0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65,
+	0x52, 0x10, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69,
+	0x64, 0x65, 0x12, 0x3e, 0x0a, 0x0e, 0x6e, 0x65, 0x78, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x18, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x62, 0x2e,
+	0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x0e, 0x6e, 0x65, 0x78, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x2c, 0x0a, 0x08, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x19,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x6e, 0x65, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x08, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x69, 0x6e, 0x67,
+	0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x98, 0x01, 0x0a, 0x0c,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x63, 0x6f, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61,
+	0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6c, 0x61,
+	0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x22, 0xe6, 0x01, 0x0a, 0x0c, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x70, 0x75, 0x74,
+	0x46, 0x70, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x69, 0x6e, 0x70, 0x75, 0x74,
+	0x46, 0x70, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x46, 0x70, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x46, 0x70,
+	0x73, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x46, 0x70, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x46, 0x70, 0x73, 0x12,
+	0x26, 0x0a, 0x0e, 0x61, 0x76, 0x67, 0x41, 0x49, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x61, 0x76, 0x67, 0x41, 0x49, 0x4c, 0x61,
+	0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x6c, 0x61, 0x73, 0x74, 0x46,
+	0x72, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x61, 0x64, 0x41, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0f, 0x6c, 0x61, 0x73, 0x74, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x61, 0x64, 0x41,
+	0x74, 0x12, 0x2c, 0x0a, 0x11, 0x6c, 0x61, 0x73, 0x74, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x50, 0x75,
+	0x73, 0x68, 0x65, 0x64, 0x41, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x6c, 0x61,
+	0x73, 0x74, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x50, 0x75, 0x73, 0x68, 0x65, 0x64, 0x41, 0x74, 0x22,
+	0x5b, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73,
+	0x63, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x69,
+	0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74,
+	0x73, 0x12, 0x29, 0x0a, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x44,
+	0x65, 0x73, 0x63, 0x52, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x41, 0x0a, 0x12,
+	0x41, 0x6c, 0x6c, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x52, 0x65,
+	0x73, 0x70, 0x12, 0x2b, 0x0a, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x44, 0x65, 0x73, 0x63, 0x52, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22,
+	0xde, 0x01, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x27, 0x0a, 0x0c, 0x64, 0x65, 0x74, 0x65,
+	0x63, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00,
+	0x52, 0x0c, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x88, 0x01,
+	0x01, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x0c, 0x0a, 0x01, 0x71, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x01, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73,
+	0x69, 0x74, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x69, 0x74, 0x65, 0x42,
+	0x0f, 0x0a, 0x0d, 0x5f, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x22, 0xad, 0x01, 0x0a, 0x0d, 0x41, 0x75, 0x64, 0x69, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52,
+	0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x16,
+	0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04,
+	0x66, 0x72, 0x6f, 0x6d, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d,
+	0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x74, 0x6f,
+	0x22, 0xb0, 0x01, 0x0a, 0x0a, 0x41, 0x75, 0x64, 0x69, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74,
+	0x69, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49,
+	0x44, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x65, 0x65, 0x72, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x65, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x72,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x22, 0x4f, 0x0a, 0x0f, 0x41, 0x75, 0x64, 0x69, 0x74, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x26, 0x0a, 0x06,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70,
+	0x62, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x65, 0x76,
+	0x65, 0x6e, 0x74, 0x73, 0x22, 0xbb, 0x01, 0x0a, 0x0d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49,
+	0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x24, 0x0a, 0x0d, 0x6d, 0x69, 0x6e, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x0d, 0x6d, 0x69, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x66, 0x72,
+	0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02,
+	0x74, 0x6f, 0x22, 0x7b, 0x0a, 0x0f, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x78, 0x31, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x02, 0x78, 0x31, 0x12, 0x0e, 0x0a, 0x02, 0x79, 0x31, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x02, 0x79, 0x31, 0x12, 0x0e, 0x0a, 0x02, 0x78, 0x32, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x02, 0x78, 0x32, 0x12, 0x0e, 0x0a, 0x02, 0x79, 0x32, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x02, 0x79, 0x32, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x63,
+	0x6f, 0x6e, 0x66, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x63, 0x6f, 0x6e, 0x66, 0x22,
+	0x80, 0x03, 0x0a, 0x0e, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x73, 0x69, 0x74, 0x65, 0x12, 0x36, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x07,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x1a, 0x0a, 0x08,
+	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x2d, 0x0a, 0x07,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x70, 0x62, 0x2e, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0x4e, 0x0a, 0x0a, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x2a, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x74, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e,
+	0x74, 0x73, 0x22, 0x86, 0x01, 0x0a, 0x0e, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x65, 0x71, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12,
+	0x1a, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x22, 0xcf, 0x01, 0x0a, 0x0b,
+	0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x06, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x65, 0x61,
+	0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x65, 0x61, 0x6b, 0x12, 0x33, 0x0a,
+	0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x70, 0x62, 0x2e, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x2e, 0x4c,
+	0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65,
+	0x6c, 0x73, 0x1a, 0x49, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x24, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x5c, 0x0a,
+	0x0a, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x65, 0x61, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x04, 0x70, 0x65, 0x61, 0x6b, 0x12, 0x24, 0x0a, 0x0d, 0x61, 0x76, 0x67, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x61, 0x76,
+	0x67, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x22, 0x9f, 0x01, 0x0a, 0x0f,
+	0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12,
+	0x16, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74,
+	0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x29, 0x0a, 0x07, 0x62,
+	0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70,
+	0x62, 0x2e, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x07, 0x62,
+	0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x25, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x74, 0x65, 0x63,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x22, 0x54, 0x0a,
+	0x0f, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x2b, 0x0a, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x52, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x22, 0x5a, 0x0a, 0x14, 0x42, 0x75, 0x6c, 0x6b, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x12, 0x30, 0x0a, 0x08, 0x73,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x52, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x10, 0x0a,
+	0x03, 0x63, 0x73, 0x76, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x63, 0x73, 0x76, 0x22,
+	0x3b, 0x0a, 0x0f, 0x42, 0x75, 0x6c, 0x6b, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52,
+	0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x03, 0x69, 0x64, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x22, 0x71, 0x0a, 0x0e,
+	0x42, 0x75, 0x6c, 0x6b, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x0e,
+	0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x14,
+	0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x12, 0x29, 0x0a, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x52, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22,
+	0x80, 0x01, 0x0a, 0x08, 0x42, 0x75, 0x6c, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x12, 0x14, 0x0a, 0x05,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x28, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x62, 0x2e, 0x42, 0x75, 0x6c,
+	0x6b, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x05, 0x69, 0x74, 0x65,
+	0x6d, 0x73, 0x22, 0x1d, 0x0a, 0x0b, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x69, 0x63, 0x52, 0x65, 0x73,
+	0x70, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f,
+	0x6b, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x32, 0x91, 0x08, 0x0a, 0x0d, 0x44,
+	0x65, 0x74, 0x65, 0x63, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x36, 0x0a, 0x0d,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x2e,
+	0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x1a, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x44, 0x65, 0x73, 0x63, 0x12, 0x36, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x12, 0x09, 0x2e, 0x70, 0x62, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x6c, 0x6c, 0x53, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x52, 0x65, 0x73, 0x70, 0x12, 0x37, 0x0a, 0x0c,
+	0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x12, 0x2e, 0x70,
+	0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71,
+	0x1a, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x12, 0x42, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x53, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x42, 0x79, 0x49, 0x44, 0x12, 0x10, 0x2e, 0x70, 0x62,
+	0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x52, 0x65, 0x71, 0x1a, 0x1a, 0x2e,
+	0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73,
+	0x63, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70, 0x12, 0x36, 0x0a, 0x0d, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x2e, 0x70, 0x62, 0x2e,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71,
+	0x1a, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73,
+	0x63, 0x12, 0x2f, 0x0a, 0x0a, 0x53, 0x74, 0x6f, 0x70, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x12,
+	0x10, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x52, 0x65,
+	0x71, 0x1a, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x69, 0x63, 0x52, 0x65,
+	0x73, 0x70, 0x12, 0x33, 0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x65, 0x44, 0x65,
+	0x74, 0x65, 0x63, 0x74, 0x12, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x49, 0x44, 0x52, 0x65, 0x71, 0x1a, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x6e, 0x65,
+	0x72, 0x69, 0x63, 0x52, 0x65, 0x73, 0x70, 0x12, 0x32, 0x0a, 0x0d, 0x52, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x52, 0x65, 0x71, 0x1a, 0x0f, 0x2e, 0x70, 0x62, 0x2e,
+	0x47, 0x65, 0x6e, 0x65, 0x72, 0x69, 0x63, 0x52, 0x65, 0x73, 0x70, 0x12, 0x3c, 0x0a, 0x12, 0x42,
+	0x75, 0x6c, 0x6b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x18, 0x2e, 0x70, 0x62, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x1a, 0x0c, 0x2e, 0x70, 0x62,
+	0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x12, 0x34, 0x0a, 0x0f, 0x42, 0x75, 0x6c,
+	0x6b, 0x53, 0x74, 0x61, 0x72, 0x74, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x12, 0x13, 0x2e, 0x70,
+	0x62, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x65,
+	0x71, 0x1a, 0x0c, 0x2e, 0x70, 0x62, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x12,
+	0x33, 0x0a, 0x0e, 0x42, 0x75, 0x6c, 0x6b, 0x53, 0x74, 0x6f, 0x70, 0x44, 0x65, 0x74, 0x65, 0x63,
+	0x74, 0x12, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x53, 0x65, 0x6c, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x1a, 0x0c, 0x2e, 0x70, 0x62, 0x2e, 0x42, 0x75, 0x6c, 0x6b,
+	0x52, 0x65, 0x73, 0x70, 0x12, 0x37, 0x0a, 0x12, 0x42, 0x75, 0x6c, 0x6b, 0x52, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x13, 0x2e, 0x70, 0x62, 0x2e,
+	0x42, 0x75, 0x6c, 0x6b, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x1a,
+	0x0c, 0x2e, 0x70, 0x62, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x12, 0x39, 0x0a,
+	0x0f, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x75, 0x64, 0x69, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73,
+	0x12, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x52, 0x65, 0x71, 0x1a, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x74, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x3f, 0x0a, 0x13, 0x53, 0x65, 0x74, 0x53,
+	0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x12,
+	0x17, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x4f, 0x76, 0x65,
+	0x72, 0x72, 0x69, 0x64, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x12, 0x39, 0x0a, 0x0e, 0x47, 0x65, 0x74,
+	0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x12, 0x2e, 0x70, 0x62,
+	0x2e, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x1a,
+	0x13, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x12, 0x38, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x74, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x11, 0x2e, 0x70, 0x62,
+	0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x1a, 0x0e,
+	0x2e, 0x70, 0x62, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x35,
+	0x0a, 0x0d, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x6e, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12,
+	0x10, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x52, 0x65,
+	0x71, 0x1a, 0x12, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x6e, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x37, 0x0a, 0x0f, 0x52, 0x65, 0x73, 0x65, 0x74, 0x4c, 0x69,
+	0x6e, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x52, 0x65, 0x71, 0x1a, 0x12, 0x2e, 0x70, 0x62, 0x2e,
+	0x4c, 0x69, 0x6e, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x42, 0x07,
+	0x5a, 0x05, 0x2e, 0x2f, 0x3b, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_detect_proto_rawDescOnce sync.Once
+	file_detect_proto_rawDescData = file_detect_proto_rawDesc
+)
+
+func file_detect_proto_rawDescGZIP() []byte {
+	file_detect_proto_rawDescOnce.Do(func() {
+		file_detect_proto_rawDescData = protoimpl.X.CompressGZIP(file_detect_proto_rawDescData)
+	})
+	return file_detect_proto_rawDescData
+}
+
+var file_detect_proto_msgTypes = make([]protoimpl.MessageInfo, 43)
+var file_detect_proto_goTypes = []any{
+	(*CreateSessionReq)(nil),       // 0: pb.CreateSessionReq
+	(*LineCounting)(nil),           // 1: pb.LineCounting
+	(*CountingLine)(nil),           // 2: pb.CountingLine
+	(*CrossCount)(nil),             // 3: pb.CrossCount
+	(*LineCount)(nil),              // 4: pb.LineCount
+	(*LineCountsResp)(nil),         // 5: pb.LineCountsResp
+	(*DetectSchedule)(nil),         // 6: pb.DetectSchedule
+	(*ScheduleWindow)(nil),         // 7: pb.ScheduleWindow
+	(*ScheduleOverride)(nil),       // 8: pb.ScheduleOverride
+	(*ScheduleTransition)(nil),     // 9: pb.ScheduleTransition
+	(*ScheduleOverrideReq)(nil),    // 10: pb.ScheduleOverrideReq
+	(*EncodingProfile)(nil),        // 11: pb.EncodingProfile
+	(*UpdateSessionReq)(nil),       // 12: pb.UpdateSessionReq
+	(*SessionIDReq)(nil),           // 13: pb.SessionIDReq
+	(*SessionDesc)(nil),            // 14: pb.SessionDesc
+	(*SessionError)(nil),           // 15: pb.SessionError
+	(*SessionStats)(nil),           // 16: pb.SessionStats
+	(*GetSessionDescByIDResp)(nil), // 17: pb.GetSessionDescByIDResp
+	(*AllSessionDescResp)(nil),     // 18: pb.AllSessionDescResp
+	(*ListSessionReq)(nil),         // 19: pb.ListSessionReq
+	(*AuditQueryReq)(nil),          // 20: pb.AuditQueryReq
+	(*AuditEvent)(nil),             // 21: pb.AuditEvent
+	(*AuditEventsResp)(nil),        // 22: pb.AuditEventsResp
+	(*EventQueryReq)(nil),          // 23: pb.EventQueryReq
+	(*DetectionResult)(nil),        // 24: pb.DetectionResult
+	(*DetectionEvent)(nil),         // 25: pb.DetectionEvent
+	(*EventsResp)(nil),             // 26: pb.EventsResp
+	(*DetectStatsReq)(nil),         // 27: pb.DetectStatsReq
+	(*DetectStats)(nil),            // 28: pb.DetectStats
+	(*LabelStats)(nil),             // 29: pb.LabelStats
+	(*DetectStatsResp)(nil),        // 30: pb.DetectStatsResp
+	(*ListSessionResp)(nil),        // 31: pb.ListSessionResp
+	(*BulkCreateSessionReq)(nil),   // 32: pb.BulkCreateSessionReq
+	(*BulkSelectorReq)(nil),        // 33: pb.BulkSelectorReq
+	(*BulkItemResult)(nil),         // 34: pb.BulkItemResult
+	(*BulkResp)(nil),               // 35: pb.BulkResp
+	(*GenericResp)(nil),            // 36: pb.GenericResp
+	(*Empty)(nil),                  // 37: pb.Empty
+	nil,                            // 38: pb.CreateSessionReq.LabelsEntry
+	nil,                            // 39: pb.LineCount.LabelsEntry
+	nil,                            // 40: pb.SessionDesc.LabelsEntry
+	nil,                            // 41: pb.DetectionEvent.LabelsEntry
+	nil,                            // 42: pb.DetectStats.LabelsEntry
+}
+var file_detect_proto_depIdxs = []int32{
+	11, // 0: pb.CreateSessionReq.encoding:type_name -> pb.EncodingProfile
+	38, // 1: pb.CreateSessionReq.labels:type_name -> pb.CreateSessionReq.LabelsEntry
+	6,  // 2: pb.CreateSessionReq.schedule:type_name -> pb.DetectSchedule
+	1,  // 3: pb.CreateSessionReq.counting:type_name -> pb.LineCounting
+	2,  // 4: pb.LineCounting.lines:type_name -> pb.CountingLine
+	39, // 5: pb.LineCount.labels:type_name -> pb.LineCount.LabelsEntry
+	4,  // 6: pb.LineCountsResp.lines:type_name -> pb.LineCount
+	7,  // 7: pb.DetectSchedule.windows:type_name -> pb.ScheduleWindow
+	11, // 8: pb.UpdateSessionReq.encoding:type_name -> pb.EncodingProfile
+	6,  // 9: pb.UpdateSessionReq.schedule:type_name -> pb.DetectSchedule
+	1,  // 10: pb.UpdateSessionReq.counting:type_name -> pb.LineCounting
+	15, // 11: pb.SessionDesc.lastError:type_name -> pb.SessionError
+	15, // 12: pb.SessionDesc.errors:type_name -> pb.SessionError
+	16, // 13: pb.SessionDesc.stats:type_name -> pb.SessionStats
+	11, // 14: pb.SessionDesc.encoding:type_name -> pb.EncodingProfile
+	40, // 15: pb.SessionDesc.labels:type_name -> pb.SessionDesc.LabelsEntry
+	6,  // 16: pb.SessionDesc.schedule:type_name -> pb.DetectSchedule
+	8,  // 17: pb.SessionDesc.scheduleOverride:type_name -> pb.ScheduleOverride
+	9,  // 18: pb.SessionDesc.nextTransition:type_name -> pb.ScheduleTransition
+	1,  // 19: pb.SessionDesc.counting:type_name -> pb.LineCounting
+	14, // 20: pb.GetSessionDescByIDResp.session:type_name -> pb.SessionDesc
+	14, // 21: pb.AllSessionDescResp.sessions:type_name -> pb.SessionDesc
+	21, // 22: pb.AuditEventsResp.events:type_name -> pb.AuditEvent
+	41, // 23: pb.DetectionEvent.labels:type_name -> pb.DetectionEvent.LabelsEntry
+	24, // 24: pb.DetectionEvent.results:type_name -> pb.DetectionResult
+	25, // 25: pb.EventsResp.events:type_name -> pb.DetectionEvent
+	42, // 26: pb.DetectStats.labels:type_name -> pb.DetectStats.LabelsEntry
+	28, // 27: pb.DetectStatsResp.buckets:type_name -> pb.DetectStats
+	28, // 28: pb.DetectStatsResp.total:type_name -> pb.DetectStats
+	14, // 29: pb.ListSessionResp.sessions:type_name -> pb.SessionDesc
+	0,  // 30: pb.BulkCreateSessionReq.sessions:type_name -> pb.CreateSessionReq
+	14, // 31: pb.BulkItemResult.session:type_name -> pb.SessionDesc
+	34, // 32: pb.BulkResp.items:type_name -> pb.BulkItemResult
+	3,  // 33: pb.LineCount.LabelsEntry.value:type_name -> pb.CrossCount
+	29, // 34: pb.DetectStats.LabelsEntry.value:type_name -> pb.LabelStats
+	0,  // 35: pb.DetectService.CreateSession:input_type -> pb.CreateSessionReq
+	37, // 36: pb.DetectService.GetAllSessionDesc:input_type -> pb.Empty
+	19, // 37: pb.DetectService.ListSessions:input_type -> pb.ListSessionReq
+	13, // 38: pb.DetectService.GetSessionDescByID:input_type -> pb.SessionIDReq
+	12, // 39: pb.DetectService.UpdateSession:input_type -> pb.UpdateSessionReq
+	13, // 40: pb.DetectService.StopDetect:input_type -> pb.SessionIDReq
+	13, // 41: pb.DetectService.ContinueDetect:input_type -> pb.SessionIDReq
+	13, // 42: pb.DetectService.RemoveSession:input_type -> pb.SessionIDReq
+	32, // 43: pb.DetectService.BulkCreateSessions:input_type -> pb.BulkCreateSessionReq
+	33, // 44: pb.DetectService.BulkStartDetect:input_type -> pb.BulkSelectorReq
+	33, // 45: pb.DetectService.BulkStopDetect:input_type -> pb.BulkSelectorReq
+	33, // 46: pb.DetectService.BulkRemoveSessions:input_type -> pb.BulkSelectorReq
+	20, // 47: pb.DetectService.ListAuditEvents:input_type -> pb.AuditQueryReq
+	10, // 48: pb.DetectService.SetScheduleOverride:input_type -> pb.ScheduleOverrideReq
+	27, // 49: pb.DetectService.GetDetectStats:input_type -> pb.DetectStatsReq
+	23, // 50: pb.DetectService.ListDetectionEvents:input_type -> pb.EventQueryReq
+	13, // 51: pb.DetectService.GetLineCounts:input_type -> pb.SessionIDReq
+	13, // 52: pb.DetectService.ResetLineCounts:input_type -> pb.SessionIDReq
+	14, // 53: pb.DetectService.CreateSession:output_type -> pb.SessionDesc
+	18, // 54: pb.DetectService.GetAllSessionDesc:output_type -> pb.AllSessionDescResp
+	31, // 55: pb.DetectService.ListSessions:output_type -> pb.ListSessionResp
+	17, // 56: pb.DetectService.GetSessionDescByID:output_type -> pb.GetSessionDescByIDResp
+	14, // 57: pb.DetectService.UpdateSession:output_type -> pb.SessionDesc
+	36, // 58: pb.DetectService.StopDetect:output_type -> pb.GenericResp
+	36, // 59: pb.DetectService.ContinueDetect:output_type -> pb.GenericResp
+	36, // 60: pb.DetectService.RemoveSession:output_type -> pb.GenericResp
+	35, // 61: pb.DetectService.BulkCreateSessions:output_type -> pb.BulkResp
+	35, // 62: pb.DetectService.BulkStartDetect:output_type -> pb.BulkResp
+	35, // 63: pb.DetectService.BulkStopDetect:output_type -> pb.BulkResp
+	35, // 64: pb.DetectService.BulkRemoveSessions:output_type -> pb.BulkResp
+	22, // 65: pb.DetectService.ListAuditEvents:output_type -> pb.AuditEventsResp
+	14, // 66: pb.DetectService.SetScheduleOverride:output_type -> pb.SessionDesc
+	30, // 67: pb.DetectService.GetDetectStats:output_type -> pb.DetectStatsResp
+	26, // 68: pb.DetectService.ListDetectionEvents:output_type -> pb.EventsResp
+	5,  // 69: pb.DetectService.GetLineCounts:output_type -> pb.LineCountsResp
+	5,  // 70: pb.DetectService.ResetLineCounts:output_type -> pb.LineCountsResp
+	53, // [53:71] is the sub-list for method output_type
+	35, // [35:53] is the sub-list for method input_type
+	35, // [35:35] is the sub-list for extension type_name
+	35, // [35:35] is the sub-list for extension extendee
+	0,  // [0:35] is the sub-list for field type_name
+}
+
+func init() { file_detect_proto_init() }
+func file_detect_proto_init() {
+	if File_detect_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_detect_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*CreateSessionReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_detect_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*LineCounting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_detect_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*CountingLine); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_detect_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*CrossCount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_detect_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*LineCount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_detect_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*LineCountsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_detect_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*DetectSchedule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_detect_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*ScheduleWindow); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
 			case 2:
 				return &v.unknownFields
 			default:
 				return nil
 			}
 		}
-		file_detect_proto_msgTypes[2].Exporter = func(v any, i int) any {
+		file_detect_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*ScheduleOverride); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_detect_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*ScheduleTransition); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_detect_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*ScheduleOverrideReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_detect_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*EncodingProfile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_detect_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*UpdateSessionReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_detect_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*SessionIDReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_detect_proto_msgTypes[14].Exporter = func(v any, i int) any {
 			switch v := v.(*SessionDesc); i {
 			case 0:
 				return &v.state
@@ -562,7 +3844,31 @@ func file_detect_proto_init() {
 				return nil
 			}
 		}
-		file_detect_proto_msgTypes[3].Exporter = func(v any, i int) any {
+		file_detect_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*SessionError); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_detect_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*SessionStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_detect_proto_msgTypes[17].Exporter = func(v any, i int) any {
 			switch v := v.(*GetSessionDescByIDResp); i {
 			case 0:
 				return &v.state
@@ -574,7 +3880,7 @@ func file_detect_proto_init() {
 				return nil
 			}
 		}
-		file_detect_proto_msgTypes[4].Exporter = func(v any, i int) any {
+		file_detect_proto_msgTypes[18].Exporter = func(v any, i int) any {
 			switch v := v.(*AllSessionDescResp); i {
 			case 0:
 				return &v.state
@@ -586,7 +3892,211 @@ func file_detect_proto_init() {
 				return nil
 			}
 		}
-		file_detect_proto_msgTypes[5].Exporter = func(v any, i int) any {
+		file_detect_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*ListSessionReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_detect_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*AuditQueryReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_detect_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*AuditEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_detect_proto_msgTypes[22].Exporter = func(v any, i int) any {
+			switch v := v.(*AuditEventsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_detect_proto_msgTypes[23].Exporter = func(v any, i int) any {
+			switch v := v.(*EventQueryReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_detect_proto_msgTypes[24].Exporter = func(v any, i int) any {
+			switch v := v.(*DetectionResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_detect_proto_msgTypes[25].Exporter = func(v any, i int) any {
+			switch v := v.(*DetectionEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_detect_proto_msgTypes[26].Exporter = func(v any, i int) any {
+			switch v := v.(*EventsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_detect_proto_msgTypes[27].Exporter = func(v any, i int) any {
+			switch v := v.(*DetectStatsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_detect_proto_msgTypes[28].Exporter = func(v any, i int) any {
+			switch v := v.(*DetectStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_detect_proto_msgTypes[29].Exporter = func(v any, i int) any {
+			switch v := v.(*LabelStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_detect_proto_msgTypes[30].Exporter = func(v any, i int) any {
+			switch v := v.(*DetectStatsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_detect_proto_msgTypes[31].Exporter = func(v any, i int) any {
+			switch v := v.(*ListSessionResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_detect_proto_msgTypes[32].Exporter = func(v any, i int) any {
+			switch v := v.(*BulkCreateSessionReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_detect_proto_msgTypes[33].Exporter = func(v any, i int) any {
+			switch v := v.(*BulkSelectorReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_detect_proto_msgTypes[34].Exporter = func(v any, i int) any {
+			switch v := v.(*BulkItemResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_detect_proto_msgTypes[35].Exporter = func(v any, i int) any {
+			switch v := v.(*BulkResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_detect_proto_msgTypes[36].Exporter = func(v any, i int) any {
 			switch v := v.(*GenericResp); i {
 			case 0:
 				return &v.state
@@ -598,7 +4108,7 @@ func file_detect_proto_init() {
 				return nil
 			}
 		}
-		file_detect_proto_msgTypes[6].Exporter = func(v any, i int) any {
+		file_detect_proto_msgTypes[37].Exporter = func(v any, i int) any {
 			switch v := v.(*Empty); i {
 			case 0:
 				return &v.state
@@ -611,13 +4121,16 @@ func file_detect_proto_init() {
 			}
 		}
 	}
+	file_detect_proto_msgTypes[10].OneofWrappers = []any{}
+	file_detect_proto_msgTypes[12].OneofWrappers = []any{}
+	file_detect_proto_msgTypes[19].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_detect_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   43,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
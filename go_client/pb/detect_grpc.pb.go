@@ -19,12 +19,24 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	DetectService_CreateSession_FullMethodName      = "/pb.DetectService/CreateSession"
-	DetectService_GetAllSessionDesc_FullMethodName  = "/pb.DetectService/GetAllSessionDesc"
-	DetectService_GetSessionDescByID_FullMethodName = "/pb.DetectService/GetSessionDescByID"
-	DetectService_StopDetect_FullMethodName         = "/pb.DetectService/StopDetect"
-	DetectService_ContinueDetect_FullMethodName     = "/pb.DetectService/ContinueDetect"
-	DetectService_RemoveSession_FullMethodName      = "/pb.DetectService/RemoveSession"
+	DetectService_CreateSession_FullMethodName       = "/pb.DetectService/CreateSession"
+	DetectService_GetAllSessionDesc_FullMethodName   = "/pb.DetectService/GetAllSessionDesc"
+	DetectService_ListSessions_FullMethodName        = "/pb.DetectService/ListSessions"
+	DetectService_GetSessionDescByID_FullMethodName  = "/pb.DetectService/GetSessionDescByID"
+	DetectService_UpdateSession_FullMethodName       = "/pb.DetectService/UpdateSession"
+	DetectService_StopDetect_FullMethodName          = "/pb.DetectService/StopDetect"
+	DetectService_ContinueDetect_FullMethodName      = "/pb.DetectService/ContinueDetect"
+	DetectService_RemoveSession_FullMethodName       = "/pb.DetectService/RemoveSession"
+	DetectService_BulkCreateSessions_FullMethodName  = "/pb.DetectService/BulkCreateSessions"
+	DetectService_BulkStartDetect_FullMethodName     = "/pb.DetectService/BulkStartDetect"
+	DetectService_BulkStopDetect_FullMethodName      = "/pb.DetectService/BulkStopDetect"
+	DetectService_BulkRemoveSessions_FullMethodName  = "/pb.DetectService/BulkRemoveSessions"
+	DetectService_ListAuditEvents_FullMethodName     = "/pb.DetectService/ListAuditEvents"
+	DetectService_SetScheduleOverride_FullMethodName = "/pb.DetectService/SetScheduleOverride"
+	DetectService_GetDetectStats_FullMethodName      = "/pb.DetectService/GetDetectStats"
+	DetectService_ListDetectionEvents_FullMethodName = "/pb.DetectService/ListDetectionEvents"
+	DetectService_GetLineCounts_FullMethodName       = "/pb.DetectService/GetLineCounts"
+	DetectService_ResetLineCounts_FullMethodName     = "/pb.DetectService/ResetLineCounts"
 )
 
 // DetectServiceClient is the client API for DetectService service.
@@ -33,10 +45,28 @@ const (
 type DetectServiceClient interface {
 	CreateSession(ctx context.Context, in *CreateSessionReq, opts ...grpc.CallOption) (*SessionDesc, error)
 	GetAllSessionDesc(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*AllSessionDescResp, error)
+	ListSessions(ctx context.Context, in *ListSessionReq, opts ...grpc.CallOption) (*ListSessionResp, error)
 	GetSessionDescByID(ctx context.Context, in *SessionIDReq, opts ...grpc.CallOption) (*GetSessionDescByIDResp, error)
+	UpdateSession(ctx context.Context, in *UpdateSessionReq, opts ...grpc.CallOption) (*SessionDesc, error)
 	StopDetect(ctx context.Context, in *SessionIDReq, opts ...grpc.CallOption) (*GenericResp, error)
 	ContinueDetect(ctx context.Context, in *SessionIDReq, opts ...grpc.CallOption) (*GenericResp, error)
 	RemoveSession(ctx context.Context, in *SessionIDReq, opts ...grpc.CallOption) (*GenericResp, error)
+	// 批量操作，允许部分失败，按项返回结果
+	BulkCreateSessions(ctx context.Context, in *BulkCreateSessionReq, opts ...grpc.CallOption) (*BulkResp, error)
+	BulkStartDetect(ctx context.Context, in *BulkSelectorReq, opts ...grpc.CallOption) (*BulkResp, error)
+	BulkStopDetect(ctx context.Context, in *BulkSelectorReq, opts ...grpc.CallOption) (*BulkResp, error)
+	BulkRemoveSessions(ctx context.Context, in *BulkSelectorReq, opts ...grpc.CallOption) (*BulkResp, error)
+	// 会话生命周期审计事件，按时间倒序；sessionID 为空时为全局查询，调用方原因通过 metadata x-audit-reason 提供
+	ListAuditEvents(ctx context.Context, in *AuditQueryReq, opts ...grpc.CallOption) (*AuditEventsResp, error)
+	// 手动覆盖识别时间计划，detect 未设置时取消覆盖
+	SetScheduleOverride(ctx context.Context, in *ScheduleOverrideReq, opts ...grpc.CallOption) (*SessionDesc, error)
+	// 会话识别统计，按时间桶聚合，会话删除后仍可查询
+	GetDetectStats(ctx context.Context, in *DetectStatsReq, opts ...grpc.CallOption) (*DetectStatsResp, error)
+	// 识别事件历史，按时间倒序；sessionID 为空时为全局查询
+	ListDetectionEvents(ctx context.Context, in *EventQueryReq, opts ...grpc.CallOption) (*EventsResp, error)
+	// 虚拟线进出计数
+	GetLineCounts(ctx context.Context, in *SessionIDReq, opts ...grpc.CallOption) (*LineCountsResp, error)
+	ResetLineCounts(ctx context.Context, in *SessionIDReq, opts ...grpc.CallOption) (*LineCountsResp, error)
 }
 
 type detectServiceClient struct {
@@ -67,6 +97,16 @@ func (c *detectServiceClient) GetAllSessionDesc(ctx context.Context, in *Empty,
 	return out, nil
 }
 
+func (c *detectServiceClient) ListSessions(ctx context.Context, in *ListSessionReq, opts ...grpc.CallOption) (*ListSessionResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSessionResp)
+	err := c.cc.Invoke(ctx, DetectService_ListSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *detectServiceClient) GetSessionDescByID(ctx context.Context, in *SessionIDReq, opts ...grpc.CallOption) (*GetSessionDescByIDResp, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetSessionDescByIDResp)
@@ -77,6 +117,16 @@ func (c *detectServiceClient) GetSessionDescByID(ctx context.Context, in *Sessio
 	return out, nil
 }
 
+func (c *detectServiceClient) UpdateSession(ctx context.Context, in *UpdateSessionReq, opts ...grpc.CallOption) (*SessionDesc, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SessionDesc)
+	err := c.cc.Invoke(ctx, DetectService_UpdateSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *detectServiceClient) StopDetect(ctx context.Context, in *SessionIDReq, opts ...grpc.CallOption) (*GenericResp, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GenericResp)
@@ -107,16 +157,134 @@ func (c *detectServiceClient) RemoveSession(ctx context.Context, in *SessionIDRe
 	return out, nil
 }
 
+func (c *detectServiceClient) BulkCreateSessions(ctx context.Context, in *BulkCreateSessionReq, opts ...grpc.CallOption) (*BulkResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkResp)
+	err := c.cc.Invoke(ctx, DetectService_BulkCreateSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *detectServiceClient) BulkStartDetect(ctx context.Context, in *BulkSelectorReq, opts ...grpc.CallOption) (*BulkResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkResp)
+	err := c.cc.Invoke(ctx, DetectService_BulkStartDetect_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *detectServiceClient) BulkStopDetect(ctx context.Context, in *BulkSelectorReq, opts ...grpc.CallOption) (*BulkResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkResp)
+	err := c.cc.Invoke(ctx, DetectService_BulkStopDetect_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *detectServiceClient) BulkRemoveSessions(ctx context.Context, in *BulkSelectorReq, opts ...grpc.CallOption) (*BulkResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkResp)
+	err := c.cc.Invoke(ctx, DetectService_BulkRemoveSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *detectServiceClient) ListAuditEvents(ctx context.Context, in *AuditQueryReq, opts ...grpc.CallOption) (*AuditEventsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuditEventsResp)
+	err := c.cc.Invoke(ctx, DetectService_ListAuditEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *detectServiceClient) SetScheduleOverride(ctx context.Context, in *ScheduleOverrideReq, opts ...grpc.CallOption) (*SessionDesc, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SessionDesc)
+	err := c.cc.Invoke(ctx, DetectService_SetScheduleOverride_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *detectServiceClient) GetDetectStats(ctx context.Context, in *DetectStatsReq, opts ...grpc.CallOption) (*DetectStatsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DetectStatsResp)
+	err := c.cc.Invoke(ctx, DetectService_GetDetectStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *detectServiceClient) ListDetectionEvents(ctx context.Context, in *EventQueryReq, opts ...grpc.CallOption) (*EventsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EventsResp)
+	err := c.cc.Invoke(ctx, DetectService_ListDetectionEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *detectServiceClient) GetLineCounts(ctx context.Context, in *SessionIDReq, opts ...grpc.CallOption) (*LineCountsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LineCountsResp)
+	err := c.cc.Invoke(ctx, DetectService_GetLineCounts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *detectServiceClient) ResetLineCounts(ctx context.Context, in *SessionIDReq, opts ...grpc.CallOption) (*LineCountsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LineCountsResp)
+	err := c.cc.Invoke(ctx, DetectService_ResetLineCounts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DetectServiceServer is the server API for DetectService service.
 // All implementations must embed UnimplementedDetectServiceServer
 // for forward compatibility
 type DetectServiceServer interface {
 	CreateSession(context.Context, *CreateSessionReq) (*SessionDesc, error)
 	GetAllSessionDesc(context.Context, *Empty) (*AllSessionDescResp, error)
+	ListSessions(context.Context, *ListSessionReq) (*ListSessionResp, error)
 	GetSessionDescByID(context.Context, *SessionIDReq) (*GetSessionDescByIDResp, error)
+	UpdateSession(context.Context, *UpdateSessionReq) (*SessionDesc, error)
 	StopDetect(context.Context, *SessionIDReq) (*GenericResp, error)
 	ContinueDetect(context.Context, *SessionIDReq) (*GenericResp, error)
 	RemoveSession(context.Context, *SessionIDReq) (*GenericResp, error)
+	// 批量操作，允许部分失败，按项返回结果
+	BulkCreateSessions(context.Context, *BulkCreateSessionReq) (*BulkResp, error)
+	BulkStartDetect(context.Context, *BulkSelectorReq) (*BulkResp, error)
+	BulkStopDetect(context.Context, *BulkSelectorReq) (*BulkResp, error)
+	BulkRemoveSessions(context.Context, *BulkSelectorReq) (*BulkResp, error)
+	// 会话生命周期审计事件，按时间倒序；sessionID 为空时为全局查询，调用方原因通过 metadata x-audit-reason 提供
+	ListAuditEvents(context.Context, *AuditQueryReq) (*AuditEventsResp, error)
+	// 手动覆盖识别时间计划，detect 未设置时取消覆盖
+	SetScheduleOverride(context.Context, *ScheduleOverrideReq) (*SessionDesc, error)
+	// 会话识别统计，按时间桶聚合，会话删除后仍可查询
+	GetDetectStats(context.Context, *DetectStatsReq) (*DetectStatsResp, error)
+	// 识别事件历史，按时间倒序；sessionID 为空时为全局查询
+	ListDetectionEvents(context.Context, *EventQueryReq) (*EventsResp, error)
+	// 虚拟线进出计数
+	GetLineCounts(context.Context, *SessionIDReq) (*LineCountsResp, error)
+	ResetLineCounts(context.Context, *SessionIDReq) (*LineCountsResp, error)
 	mustEmbedUnimplementedDetectServiceServer()
 }
 
@@ -130,9 +298,15 @@ func (UnimplementedDetectServiceServer) CreateSession(context.Context, *CreateSe
 func (UnimplementedDetectServiceServer) GetAllSessionDesc(context.Context, *Empty) (*AllSessionDescResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAllSessionDesc not implemented")
 }
+func (UnimplementedDetectServiceServer) ListSessions(context.Context, *ListSessionReq) (*ListSessionResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessions not implemented")
+}
 func (UnimplementedDetectServiceServer) GetSessionDescByID(context.Context, *SessionIDReq) (*GetSessionDescByIDResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetSessionDescByID not implemented")
 }
+func (UnimplementedDetectServiceServer) UpdateSession(context.Context, *UpdateSessionReq) (*SessionDesc, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSession not implemented")
+}
 func (UnimplementedDetectServiceServer) StopDetect(context.Context, *SessionIDReq) (*GenericResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StopDetect not implemented")
 }
@@ -142,6 +316,36 @@ func (UnimplementedDetectServiceServer) ContinueDetect(context.Context, *Session
 func (UnimplementedDetectServiceServer) RemoveSession(context.Context, *SessionIDReq) (*GenericResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveSession not implemented")
 }
+func (UnimplementedDetectServiceServer) BulkCreateSessions(context.Context, *BulkCreateSessionReq) (*BulkResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkCreateSessions not implemented")
+}
+func (UnimplementedDetectServiceServer) BulkStartDetect(context.Context, *BulkSelectorReq) (*BulkResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkStartDetect not implemented")
+}
+func (UnimplementedDetectServiceServer) BulkStopDetect(context.Context, *BulkSelectorReq) (*BulkResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkStopDetect not implemented")
+}
+func (UnimplementedDetectServiceServer) BulkRemoveSessions(context.Context, *BulkSelectorReq) (*BulkResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkRemoveSessions not implemented")
+}
+func (UnimplementedDetectServiceServer) ListAuditEvents(context.Context, *AuditQueryReq) (*AuditEventsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
+func (UnimplementedDetectServiceServer) SetScheduleOverride(context.Context, *ScheduleOverrideReq) (*SessionDesc, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetScheduleOverride not implemented")
+}
+func (UnimplementedDetectServiceServer) GetDetectStats(context.Context, *DetectStatsReq) (*DetectStatsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDetectStats not implemented")
+}
+func (UnimplementedDetectServiceServer) ListDetectionEvents(context.Context, *EventQueryReq) (*EventsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDetectionEvents not implemented")
+}
+func (UnimplementedDetectServiceServer) GetLineCounts(context.Context, *SessionIDReq) (*LineCountsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLineCounts not implemented")
+}
+func (UnimplementedDetectServiceServer) ResetLineCounts(context.Context, *SessionIDReq) (*LineCountsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetLineCounts not implemented")
+}
 func (UnimplementedDetectServiceServer) mustEmbedUnimplementedDetectServiceServer() {}
 
 // UnsafeDetectServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -191,6 +395,24 @@ func _DetectService_GetAllSessionDesc_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DetectService_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSessionReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DetectServiceServer).ListSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DetectService_ListSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DetectServiceServer).ListSessions(ctx, req.(*ListSessionReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DetectService_GetSessionDescByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SessionIDReq)
 	if err := dec(in); err != nil {
@@ -209,6 +431,24 @@ func _DetectService_GetSessionDescByID_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DetectService_UpdateSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSessionReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DetectServiceServer).UpdateSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DetectService_UpdateSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DetectServiceServer).UpdateSession(ctx, req.(*UpdateSessionReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DetectService_StopDetect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SessionIDReq)
 	if err := dec(in); err != nil {
@@ -263,6 +503,186 @@ func _DetectService_RemoveSession_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DetectService_BulkCreateSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkCreateSessionReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DetectServiceServer).BulkCreateSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DetectService_BulkCreateSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DetectServiceServer).BulkCreateSessions(ctx, req.(*BulkCreateSessionReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DetectService_BulkStartDetect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkSelectorReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DetectServiceServer).BulkStartDetect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DetectService_BulkStartDetect_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DetectServiceServer).BulkStartDetect(ctx, req.(*BulkSelectorReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DetectService_BulkStopDetect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkSelectorReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DetectServiceServer).BulkStopDetect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DetectService_BulkStopDetect_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DetectServiceServer).BulkStopDetect(ctx, req.(*BulkSelectorReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DetectService_BulkRemoveSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkSelectorReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DetectServiceServer).BulkRemoveSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DetectService_BulkRemoveSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DetectServiceServer).BulkRemoveSessions(ctx, req.(*BulkSelectorReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DetectService_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuditQueryReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DetectServiceServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DetectService_ListAuditEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DetectServiceServer).ListAuditEvents(ctx, req.(*AuditQueryReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DetectService_SetScheduleOverride_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleOverrideReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DetectServiceServer).SetScheduleOverride(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DetectService_SetScheduleOverride_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DetectServiceServer).SetScheduleOverride(ctx, req.(*ScheduleOverrideReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DetectService_GetDetectStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DetectStatsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DetectServiceServer).GetDetectStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DetectService_GetDetectStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DetectServiceServer).GetDetectStats(ctx, req.(*DetectStatsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DetectService_ListDetectionEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EventQueryReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DetectServiceServer).ListDetectionEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DetectService_ListDetectionEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DetectServiceServer).ListDetectionEvents(ctx, req.(*EventQueryReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DetectService_GetLineCounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionIDReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DetectServiceServer).GetLineCounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DetectService_GetLineCounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DetectServiceServer).GetLineCounts(ctx, req.(*SessionIDReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DetectService_ResetLineCounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionIDReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DetectServiceServer).ResetLineCounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DetectService_ResetLineCounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DetectServiceServer).ResetLineCounts(ctx, req.(*SessionIDReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DetectService_ServiceDesc is the grpc.ServiceDesc for DetectService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -278,10 +698,18 @@ var DetectService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAllSessionDesc",
 			Handler:    _DetectService_GetAllSessionDesc_Handler,
 		},
+		{
+			MethodName: "ListSessions",
+			Handler:    _DetectService_ListSessions_Handler,
+		},
 		{
 			MethodName: "GetSessionDescByID",
 			Handler:    _DetectService_GetSessionDescByID_Handler,
 		},
+		{
+			MethodName: "UpdateSession",
+			Handler:    _DetectService_UpdateSession_Handler,
+		},
 		{
 			MethodName: "StopDetect",
 			Handler:    _DetectService_StopDetect_Handler,
@@ -294,6 +722,46 @@ var DetectService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveSession",
 			Handler:    _DetectService_RemoveSession_Handler,
 		},
+		{
+			MethodName: "BulkCreateSessions",
+			Handler:    _DetectService_BulkCreateSessions_Handler,
+		},
+		{
+			MethodName: "BulkStartDetect",
+			Handler:    _DetectService_BulkStartDetect_Handler,
+		},
+		{
+			MethodName: "BulkStopDetect",
+			Handler:    _DetectService_BulkStopDetect_Handler,
+		},
+		{
+			MethodName: "BulkRemoveSessions",
+			Handler:    _DetectService_BulkRemoveSessions_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _DetectService_ListAuditEvents_Handler,
+		},
+		{
+			MethodName: "SetScheduleOverride",
+			Handler:    _DetectService_SetScheduleOverride_Handler,
+		},
+		{
+			MethodName: "GetDetectStats",
+			Handler:    _DetectService_GetDetectStats_Handler,
+		},
+		{
+			MethodName: "ListDetectionEvents",
+			Handler:    _DetectService_ListDetectionEvents_Handler,
+		},
+		{
+			MethodName: "GetLineCounts",
+			Handler:    _DetectService_GetLineCounts_Handler,
+		},
+		{
+			MethodName: "ResetLineCounts",
+			Handler:    _DetectService_ResetLineCounts_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "detect.proto",
@@ -0,0 +1,164 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"strings"
+)
+
+var (
+	ErrUnauthenticated = errors.New("unauthenticated")
+)
+
+// 认证方式
+const (
+	MethodAPIKey = "api-key"
+	MethodJWT    = "jwt"
+	MethodConfig = "config" // 配置文件声明的会话，由引擎内部调和
+)
+
+// Principal 调用方身份
+type Principal struct {
+	Subject string // 调用方标识：API Key 名称或 JWT sub
+	Tenant  string // 所属租户
+	Admin   bool   // 管理员可访问所有租户
+	Method  string // 认证方式 api-key jwt
+}
+
+// CanAccess 是否可访问指定租户的资源，nil 表示未开启认证，不做限制
+func (p *Principal) CanAccess(tenant string) bool {
+	return p == nil || p.Admin || p.Tenant == tenant
+}
+
+// GetTenant 调用方租户，nil 返回空字符串
+func (p *Principal) GetTenant() string {
+	if p == nil {
+		return ""
+	}
+	return p.Tenant
+}
+
+// String 用于日志与审计的调用方描述
+func (p *Principal) String() string {
+	if p == nil {
+		return "anonymous"
+	}
+	return fmt.Sprintf("%s:%s@%s", p.Method, p.Subject, p.Tenant)
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext 获取调用方身份，未开启认证时返回 nil
+func FromContext(ctx context.Context) *Principal {
+	p, _ := ctx.Value(principalKey{}).(*Principal)
+	return p
+}
+
+// APIKey API Key 配置
+type APIKey struct {
+	Name   string `toml:"name"`   // 名称，用于日志与审计
+	Key    string `toml:"key"`    // 密钥
+	Tenant string `toml:"tenant"` // 所属租户
+	Admin  bool   `toml:"admin"`  // 是否管理员
+}
+
+// JWTConfig JWT 校验配置，仅支持 HMAC 签名，token 必须携带 exp
+type JWTConfig struct {
+	Secret      string `toml:"secret"`       // HMAC 密钥
+	Issuer      string `toml:"issuer"`       // 签发方，为空不校验
+	Audience    string `toml:"audience"`     // 受众，为空不校验
+	TenantClaim string `toml:"tenant-claim"` // 租户字段名，默认 tenant
+	AdminClaim  string `toml:"admin-claim"`  // 管理员字段名（bool），默认 admin
+}
+
+// Authenticator 校验 API Key 与 JWT
+type Authenticator struct {
+	apiKeys []APIKey
+	jwt     JWTConfig
+	parser  *jwt.Parser
+}
+
+func NewAuthenticator(apiKeys []APIKey, jwtConfig JWTConfig) *Authenticator {
+	if jwtConfig.TenantClaim == "" {
+		jwtConfig.TenantClaim = "tenant"
+	}
+	if jwtConfig.AdminClaim == "" {
+		jwtConfig.AdminClaim = "admin"
+	}
+
+	options := []jwt.ParserOption{jwt.WithValidMethods([]string{"HS256", "HS384", "HS512"}), jwt.WithExpirationRequired()}
+	if jwtConfig.Issuer != "" {
+		options = append(options, jwt.WithIssuer(jwtConfig.Issuer))
+	}
+	if jwtConfig.Audience != "" {
+		options = append(options, jwt.WithAudience(jwtConfig.Audience))
+	}
+
+	return &Authenticator{
+		apiKeys: apiKeys,
+		jwt:     jwtConfig,
+		parser:  jwt.NewParser(options...),
+	}
+}
+
+// Authenticate 校验凭证：apiKey 非空时按 API Key 校验，否则按 Authorization Bearer JWT 校验
+func (a *Authenticator) Authenticate(apiKey, authorization string) (*Principal, error) {
+	if apiKey != "" {
+		return a.authenticateAPIKey(apiKey)
+	}
+
+	token, ok := strings.CutPrefix(authorization, "Bearer ")
+	if !ok || token == "" {
+		return nil, fmt.Errorf("%w: missing api key or bearer token", ErrUnauthenticated)
+	}
+	return a.authenticateJWT(strings.TrimSpace(token))
+}
+
+func (a *Authenticator) authenticateAPIKey(key string) (*Principal, error) {
+	for i := range a.apiKeys {
+		if subtle.ConstantTimeCompare([]byte(a.apiKeys[i].Key), []byte(key)) == 1 {
+			return &Principal{
+				Subject: a.apiKeys[i].Name,
+				Tenant:  a.apiKeys[i].Tenant,
+				Admin:   a.apiKeys[i].Admin,
+				Method:  MethodAPIKey,
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: invalid api key", ErrUnauthenticated)
+}
+
+func (a *Authenticator) authenticateJWT(tokenString string) (*Principal, error) {
+	if a.jwt.Secret == "" {
+		return nil, fmt.Errorf("%w: jwt not enabled", ErrUnauthenticated)
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := a.parser.ParseWithClaims(tokenString, claims, func(*jwt.Token) (any, error) {
+		return []byte(a.jwt.Secret), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnauthenticated, err)
+	}
+
+	subject, _ := claims.GetSubject()
+	tenant, _ := claims[a.jwt.TenantClaim].(string)
+	admin, _ := claims[a.jwt.AdminClaim].(bool)
+	if tenant == "" && !admin {
+		return nil, fmt.Errorf("%w: missing %s claim", ErrUnauthenticated, a.jwt.TenantClaim)
+	}
+
+	return &Principal{
+		Subject: subject,
+		Tenant:  tenant,
+		Admin:   admin,
+		Method:  MethodJWT,
+	}, nil
+}
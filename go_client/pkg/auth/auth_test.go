@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"errors"
+	"github.com/golang-jwt/jwt/v5"
+	"testing"
+	"time"
+)
+
+func TestAuthenticate(t *testing.T) {
+	authenticator := NewAuthenticator(
+		[]APIKey{{Name: "ops", Key: "k1", Tenant: "t1"}},
+		JWTConfig{Secret: "secret", Issuer: "video-detect"},
+	)
+
+	sign := func(claims jwt.MapClaims) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("secret"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return "Bearer " + token
+	}
+	exp := time.Now().Add(time.Hour).Unix()
+
+	tests := []struct {
+		name          string
+		apiKey        string
+		authorization string
+		wantTenant    string
+		wantAdmin     bool
+		wantErr       bool
+	}{
+		{name: "api key", apiKey: "k1", wantTenant: "t1"},
+		{name: "invalid api key", apiKey: "k2", wantErr: true},
+		{name: "missing credential", wantErr: true},
+		{name: "jwt", authorization: sign(jwt.MapClaims{"iss": "video-detect", "tenant": "t2", "exp": exp}), wantTenant: "t2"},
+		{name: "jwt admin", authorization: sign(jwt.MapClaims{"iss": "video-detect", "admin": true, "exp": exp}), wantAdmin: true},
+		{name: "jwt wrong issuer", authorization: sign(jwt.MapClaims{"iss": "other", "tenant": "t2", "exp": exp}), wantErr: true},
+		{name: "jwt expired", authorization: sign(jwt.MapClaims{"iss": "video-detect", "tenant": "t2", "exp": time.Now().Add(-time.Hour).Unix()}), wantErr: true},
+		{name: "jwt missing tenant", authorization: sign(jwt.MapClaims{"iss": "video-detect", "exp": exp}), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := authenticator.Authenticate(tt.apiKey, tt.authorization)
+			if tt.wantErr {
+				if !errors.Is(err, ErrUnauthenticated) {
+					t.Fatalf("want ErrUnauthenticated, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if principal.Tenant != tt.wantTenant || principal.Admin != tt.wantAdmin {
+				t.Fatalf("got %+v", principal)
+			}
+		})
+	}
+}
+
+func TestPrincipalCanAccess(t *testing.T) {
+	var anonymous *Principal
+	if !anonymous.CanAccess("t1") {
+		t.Fatal("nil principal should access all tenants")
+	}
+	if !(&Principal{Admin: true}).CanAccess("t1") {
+		t.Fatal("admin should access all tenants")
+	}
+	if (&Principal{Tenant: "t2"}).CanAccess("t1") {
+		t.Fatal("tenant should not access other tenants")
+	}
+}
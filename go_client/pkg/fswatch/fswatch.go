@@ -0,0 +1,73 @@
+package fswatch
+
+import (
+	"context"
+	"fmt"
+	"github.com/fsnotify/fsnotify"
+	"path/filepath"
+	"time"
+)
+
+const debounce = 300 * time.Millisecond // 编辑器保存时会产生多个事件，合并为一次回调
+
+// Watch 监听文件所在目录（兼容 Kubernetes ConfigMap/Secret 的 ..data 符号链接替换），
+// 文件写入、创建或替换后回调 onChange，ctx 结束后停止
+func Watch(ctx context.Context, files []string, onChange func(), onError func(error)) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	watched := map[string]bool{}
+	dirs := map[string]bool{}
+	for _, file := range files {
+		if file == "" {
+			continue
+		}
+		watched[filepath.Clean(file)] = true
+		dir := filepath.Dir(file)
+		if dirs[dir] {
+			continue
+		}
+		dirs[dir] = true
+		if err := watcher.Add(dir); err != nil {
+			_ = watcher.Close()
+			return fmt.Errorf("watch %s: %w", dir, err)
+		}
+	}
+
+	go func() {
+		defer watcher.Close()
+		timer := time.NewTimer(debounce)
+		timer.Stop()
+		defer timer.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				// Kubernetes 更新时替换 ..data 符号链接，文件本身无事件
+				if !watched[filepath.Clean(event.Name)] && filepath.Base(event.Name) != "..data" {
+					continue
+				}
+				if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) && !event.Has(fsnotify.Rename) {
+					continue
+				}
+				timer.Reset(debounce)
+			case <-timer.C:
+				onChange()
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				if onError != nil {
+					onError(err)
+				}
+			}
+		}
+	}()
+	return nil
+}
@@ -4,30 +4,54 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"gopkg.in/natefinch/lumberjack.v2"
+	"os"
 	"strings"
+	"time"
 )
 
-func NewLogWithSplitting(sp LogSplitting, loglevel string) *zap.Logger {
-	// lower case loglevel
-	loglevel = strings.ToLower(loglevel)
+// Option 日志输出选项
+type Option func(o *options)
 
-	var level zapcore.Level
-	switch loglevel {
-	case "debug":
-		level = zap.DebugLevel
-	case "info":
-		level = zap.InfoLevel
-	case "error":
-		level = zap.ErrorLevel
-	default:
-		level = zap.InfoLevel
+type options struct {
+	json   bool
+	stdout bool
+}
+
+// WithJSON 使用 JSON 格式输出，默认 console 格式
+func WithJSON(enable bool) Option {
+	return func(o *options) {
+		o.json = enable
 	}
-	atomicLevel := zap.NewAtomicLevel()
-	atomicLevel.SetLevel(level)
+}
+
+// WithStdout 同时输出到标准输出，便于容器采集日志
+func WithStdout(enable bool) Option {
+	return func(o *options) {
+		o.stdout = enable
+	}
+}
+
+// NewLogWithSplitting 创建日志，返回的 AtomicLevel 可在运行时调整日志等级
+func NewLogWithSplitting(sp LogSplitting, loglevel string, opts ...Option) (*zap.Logger, zap.AtomicLevel) {
+	var o options
+	for i := range opts {
+		opts[i](&o)
+	}
+
+	encoder := zapcore.NewConsoleEncoder(getEncoderConfig())
+	if o.json {
+		encoder = zapcore.NewJSONEncoder(getEncoderConfig())
+	}
+	writer := setLogWriter(sp)
+	if o.stdout {
+		writer = zapcore.NewMultiWriteSyncer(writer, zapcore.Lock(os.Stdout))
+	}
+
+	atomicLevel := zap.NewAtomicLevelAt(ParseLevel(loglevel))
 	core := zapcore.NewCore(
-		getEncoder(),
-		setLogWriter(sp),
-		level)
+		encoder,
+		writer,
+		atomicLevel)
 
 	// 开启开发模式，堆栈跟踪
 	caller := zap.AddCaller()
@@ -36,11 +60,35 @@ func NewLogWithSplitting(sp LogSplitting, loglevel string) *zap.Logger {
 	// 设置初始化字段,如：添加一个服务器名称
 	// filed := zap.Fields(zap.String("serviceName", "serviceName"))
 	// 构造日志
-	return zap.New(core, caller, development)
+	return zap.New(core, caller, development), atomicLevel
 }
 
-func getEncoder() zapcore.Encoder {
-	return zapcore.NewConsoleEncoder(zapcore.EncoderConfig{
+// ParseLevel 解析日志等级，大小写不敏感，未知等级使用 info
+func ParseLevel(loglevel string) zapcore.Level {
+	// lower case loglevel
+	switch strings.ToLower(loglevel) {
+	case "debug":
+		return zap.DebugLevel
+	case "info":
+		return zap.InfoLevel
+	case "warn":
+		return zap.WarnLevel
+	case "error":
+		return zap.ErrorLevel
+	default:
+		return zap.InfoLevel
+	}
+}
+
+// Sampled 对相同等级与消息的日志采样：每秒前 first 条全部输出，之后每 thereafter 条输出一条，用于逐帧等高频日志
+func Sampled(l *zap.Logger, first, thereafter int) *zap.Logger {
+	return l.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewSamplerWithOptions(core, time.Second, first, thereafter)
+	}))
+}
+
+func getEncoderConfig() zapcore.EncoderConfig {
+	return zapcore.EncoderConfig{
 		TimeKey:        "time",
 		LevelKey:       "level",
 		NameKey:        "logger",
@@ -53,7 +101,7 @@ func getEncoder() zapcore.Encoder {
 		EncodeDuration: zapcore.SecondsDurationEncoder, //
 		EncodeCaller:   zapcore.FullCallerEncoder,      // 全路径编码器
 		EncodeName:     zapcore.FullNameEncoder,
-	})
+	}
 }
 
 /*
@@ -87,3 +135,33 @@ func setLogWriter(sp LogSplitting) zapcore.WriteSyncer {
 		Compress:   sp.Compress,
 	})
 }
+
+// WithLevelOverride 返回子日志：enabled 返回 true 的等级即使低于全局等级也会输出，用于单个会话开启 debug 日志
+func WithLevelOverride(l *zap.Logger, enabled func(zapcore.Level) bool) *zap.Logger {
+	return l.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &overrideCore{Core: core, enabled: enabled}
+	}))
+}
+
+type overrideCore struct {
+	zapcore.Core
+	enabled func(zapcore.Level) bool
+}
+
+func (c *overrideCore) Enabled(level zapcore.Level) bool {
+	return c.enabled(level) || c.Core.Enabled(level)
+}
+
+func (c *overrideCore) With(fields []zapcore.Field) zapcore.Core {
+	return &overrideCore{Core: c.Core.With(fields), enabled: c.enabled}
+}
+
+func (c *overrideCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Core.Enabled(ent.Level) {
+		return c.Core.Check(ent, ce)
+	}
+	if c.enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
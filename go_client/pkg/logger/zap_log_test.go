@@ -0,0 +1,110 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestNewLogWithSplittingJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	l, level := NewLogWithSplitting(LogSplitting{FileName: path, MaxSize: 1}, "info", WithJSON(true))
+	l.Debug("hidden")
+	l.Info("session started", zap.String("session_id", "cam-1"))
+	level.SetLevel(zap.DebugLevel)
+	l.Debug("shown")
+	_ = l.Sync()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("want 2 lines, got %q", data)
+	}
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("not json: %q: %v", lines[0], err)
+	}
+	if entry["msg"] != "session started" || entry["level"] != "info" || entry["session_id"] != "cam-1" {
+		t.Fatalf("unexpected entry: %+v", entry)
+	}
+
+	// 默认 console 格式
+	path = filepath.Join(t.TempDir(), "console.log")
+	l, _ = NewLogWithSplitting(LogSplitting{FileName: path, MaxSize: 1}, "info")
+	l.Info("session started")
+	_ = l.Sync()
+	if data, _ = os.ReadFile(path); json.Valid(bytes.TrimSpace(data)) || !bytes.Contains(data, []byte("session started")) {
+		t.Fatalf("want console line, got %q", data)
+	}
+}
+
+func TestNewLogWithSplittingStdout(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	path := filepath.Join(t.TempDir(), "app.log")
+	l, _ := NewLogWithSplitting(LogSplitting{FileName: path, MaxSize: 1}, "info", WithStdout(true))
+	l.Info("to both")
+	_ = l.Sync()
+	_ = w.Close()
+
+	out, _ := io.ReadAll(r)
+	file, _ := os.ReadFile(path)
+	if !bytes.Contains(out, []byte("to both")) || !bytes.Contains(file, []byte("to both")) {
+		t.Fatalf("want line in stdout and file, got stdout=%q file=%q", out, file)
+	}
+}
+
+func TestSampled(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	l := Sampled(zap.New(core), 2, 3)
+	for i := 0; i < 10; i++ {
+		l.Debug("frame read")
+	}
+	l.Debug("other")
+
+	// 前 2 条全部输出，之后每 3 条输出一条：第 1 2 5 8 条
+	if n := logs.FilterMessage("frame read").Len(); n != 4 {
+		t.Fatalf("want 4 sampled entries, got %d", n)
+	}
+	if logs.FilterMessage("other").Len() != 1 {
+		t.Fatal("sampling must be per message")
+	}
+}
+
+func TestWithLevelOverride(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	var debug atomic.Bool
+	l := WithLevelOverride(zap.New(core), func(level zapcore.Level) bool {
+		return debug.Load() && level >= zap.DebugLevel
+	}).With(zap.String("session_id", "cam-1"))
+
+	l.Debug("hidden")
+	debug.Store(true)
+	l.Debug("shown")
+	l.Info("info")
+
+	entries := logs.All()
+	if len(entries) != 2 || entries[0].Message != "shown" || entries[1].Message != "info" {
+		t.Fatalf("got %+v", entries)
+	}
+	if entries[0].ContextMap()["session_id"] != "cam-1" {
+		t.Fatalf("missing session field: %+v", entries[0].ContextMap())
+	}
+}
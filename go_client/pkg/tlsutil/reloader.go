@@ -0,0 +1,96 @@
+package tlsutil
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"go_client/pkg/fswatch"
+	"os"
+	"sync/atomic"
+)
+
+// Reloader 证书热加载：监听证书、私钥与客户端 CA 文件变化并重新加载，加载失败时保留旧证书
+type Reloader struct {
+	certFile string
+	keyFile  string
+	caFile   string
+	logger   *zap.Logger
+
+	cert   atomic.Pointer[tls.Certificate]
+	caPool atomic.Pointer[x509.CertPool]
+}
+
+// NewReloader 加载证书，caFile 为空表示不校验客户端证书
+func NewReloader(certFile, keyFile, caFile string, logger *zap.Logger) (*Reloader, error) {
+	r := &Reloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+		caFile:   caFile,
+		logger:   logger,
+	}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *Reloader) load() error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("load tls key pair: %w", err)
+	}
+
+	var caPool *x509.CertPool
+	if r.caFile != "" {
+		pem, err := os.ReadFile(r.caFile)
+		if err != nil {
+			return fmt.Errorf("read client ca: %w", err)
+		}
+		caPool = x509.NewCertPool()
+		if !caPool.AppendCertsFromPEM(pem) {
+			return errors.New("no valid certificate found in client ca file")
+		}
+	}
+
+	r.cert.Store(&cert)
+	r.caPool.Store(caPool)
+	return nil
+}
+
+// HasClientCA 是否配置了客户端 CA
+func (r *Reloader) HasClientCA() bool {
+	return r.caFile != ""
+}
+
+// ServerConfig 生成服务端 TLS 配置，每次握手使用最新加载的证书与客户端 CA
+func (r *Reloader) ServerConfig(clientAuth tls.ClientAuthType, nextProtos []string) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: nextProtos,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				NextProtos:   nextProtos,
+				Certificates: []tls.Certificate{*r.cert.Load()},
+				ClientCAs:    r.caPool.Load(),
+				ClientAuth:   clientAuth,
+			}, nil
+		},
+	}
+}
+
+// Watch 监听证书文件变化并重新加载，ctx 结束后停止
+func (r *Reloader) Watch(ctx context.Context) error {
+	return fswatch.Watch(ctx, []string{r.certFile, r.keyFile, r.caFile}, func() {
+		if err := r.load(); err != nil {
+			r.logger.Warn("tls certificate reload failed, keep previous certificate", zap.Error(err))
+			return
+		}
+		r.logger.Info("🔐 tls certificate reloaded")
+	}, func(err error) {
+		r.logger.Warn("tls certificate watcher error", zap.Error(err))
+	})
+}
@@ -0,0 +1,127 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"io"
+	"net/http"
+)
+
+const (
+	instrumentationName = "go_client"
+	defaultServiceName  = "video_detect"
+	defaultSampleRatio  = 0.1 // 逐帧识别的 span 各自开启新 trace，默认仅采样一部分
+)
+
+// Option 链路追踪选项
+type Option func(o *options)
+
+type options struct {
+	serviceName string
+	sampleRatio float64
+}
+
+// WithServiceName 服务名，为空时使用默认值 video_detect
+func WithServiceName(name string) Option {
+	return func(o *options) {
+		o.serviceName = name
+	}
+}
+
+// WithSampleRatio 新 trace 的采样比例 (0, 1]，不大于 0 时使用默认值 0.1；上游已采样时跟随上游
+func WithSampleRatio(ratio float64) Option {
+	return func(o *options) {
+		o.sampleRatio = ratio
+	}
+}
+
+// Tracer 获取全局 Tracer，未开启链路追踪时为 noop
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Inject 将 ctx 中的 trace 上下文写入 HTTP 请求头（traceparent、tracestate、baggage）
+func Inject(ctx context.Context, header http.Header) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// End 结束 span，err 不为空时记录错误并标记失败
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// SetupPropagator 设置 W3C TraceContext 传播器：未开启链路追踪时上游请求携带的 trace 上下文仍会透传给识别服务
+func SetupPropagator() {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+}
+
+// Setup 设置传播器与通过 exporter 导出的全局 TracerProvider，返回关闭函数用于刷新剩余 span
+func Setup(exporter sdktrace.SpanExporter, opts ...Option) (shutdown func(context.Context) error, err error) {
+	var o options
+	for i := range opts {
+		opts[i](&o)
+	}
+	if o.serviceName == "" {
+		o.serviceName = defaultServiceName
+	}
+	if o.sampleRatio <= 0 {
+		o.sampleRatio = defaultSampleRatio
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(attribute.String("service.name", o.serviceName)))
+	if err != nil {
+		return nil, fmt.Errorf("tracing resource: %w", err)
+	}
+
+	SetupPropagator()
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(o.sampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// NewOTLPGRPCExporter OTLP gRPC 导出，endpoint 为空时使用 OTEL_EXPORTER_OTLP_ENDPOINT
+func NewOTLPGRPCExporter(ctx context.Context, endpoint string, insecure bool) (sdktrace.SpanExporter, error) {
+	opts := make([]otlptracegrpc.Option, 0, 2)
+	if endpoint != "" {
+		opts = append(opts, otlptracegrpc.WithEndpoint(endpoint))
+	}
+	if insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	return otlptracegrpc.New(ctx, opts...)
+}
+
+// NewOTLPHTTPExporter OTLP HTTP 导出，endpoint 为空时使用 OTEL_EXPORTER_OTLP_ENDPOINT
+func NewOTLPHTTPExporter(ctx context.Context, endpoint string, insecure bool) (sdktrace.SpanExporter, error) {
+	opts := make([]otlptracehttp.Option, 0, 2)
+	if endpoint != "" {
+		opts = append(opts, otlptracehttp.WithEndpoint(endpoint))
+	}
+	if insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	return otlptracehttp.New(ctx, opts...)
+}
+
+// NewWriterExporter 每行一个 span（JSON）写入 w
+func NewWriterExporter(w io.Writer) (sdktrace.SpanExporter, error) {
+	return stdouttrace.New(stdouttrace.WithWriter(w))
+}
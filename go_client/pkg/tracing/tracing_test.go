@@ -0,0 +1,59 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestSetupWriterExporter(t *testing.T) {
+	var buf bytes.Buffer
+	exporter, err := NewWriterExporter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shutdown, err := Setup(exporter, WithSampleRatio(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, span := Tracer().Start(context.Background(), "test.detect")
+	header := http.Header{}
+	Inject(ctx, header)
+	End(span, nil)
+	if header.Get("traceparent") == "" {
+		t.Fatal("traceparent header not injected")
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `"Name":"test.detect"`) {
+		t.Fatalf("span not exported: %s", buf.String())
+	}
+}
+
+func TestSetupDefaultSampleRatio(t *testing.T) {
+	exporter, err := NewWriterExporter(&bytes.Buffer{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	shutdown, err := Setup(exporter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer shutdown(context.Background())
+
+	// 默认只采样部分新 trace，期望约 10%
+	sampled := 0
+	for i := 0; i < 1000; i++ {
+		_, span := Tracer().Start(context.Background(), "test.frame")
+		if span.SpanContext().IsSampled() {
+			sampled++
+		}
+		span.End()
+	}
+	if sampled < 40 || sampled > 200 {
+		t.Fatalf("want about 100 of 1000 root spans sampled, got %d", sampled)
+	}
+}